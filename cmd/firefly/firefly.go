@@ -42,7 +42,7 @@ func main() {
 	signal.Notify(c, os.Interrupt)
 	go func() {
 		for range c {
-			fmt.Println("\n\r"+design.STATUS.WARNING, "CTRL+C pressed - Exiting")
+			fmt.Fprintln(os.Stderr, "\n\r"+design.STATUS.WARNING, "CTRL+C pressed - Exiting")
 			os.Exit(130)
 		}
 	}()
@@ -63,8 +63,8 @@ func main() {
 		log.Fatal(err)
 	}
 
-	//Display summary of the process:
-	fmt.Printf(
+	//Display summary of the process (stderr):
+	fmt.Fprintf(os.Stderr,
 		"%s\033[1;32m\u2713\033[0m Process finished: Requests/Responses:[%d/%d], Scanned:[\033[1;32m%d\033[0m], Behavior:[\033[1;33m%d\033[0m], Filtered:[\033[1;36m%d\033[0m], Error:[\033[31m%d\033[0m], Time:[%v]\n",
 		global.TERMINAL_CLEAR,
 		Statistic.Request.GetCount(),
@@ -2,13 +2,15 @@ package banner
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/Brum3ns/firefly/internal/version"
 	"github.com/Brum3ns/firefly/pkg/design"
 )
 
+// Note : (The banner is written to stderr to keep stdout clean for the results)
 func Banner() {
-	fmt.Printf(`                             
+	fmt.Fprintf(os.Stderr, `                             
    / __7 o          / _7/¯7  
   / _7 /¯7 /¯_7¯-_)/ _7/ /\¯\/7
  /_/  /_/ /_/ \__7/_/ /_/  ) /
@@ -20,5 +22,5 @@ func Banner() {
 }
 
 func Disclaimer() {
-	fmt.Println(design.ICON.AWARE + " Stay ethical. The creator of the tool is not responsible for any misuse or damage.")
+	fmt.Fprintln(os.Stderr, design.ICON.AWARE+" Stay ethical. The creator of the tool is not responsible for any misuse or damage.")
 }
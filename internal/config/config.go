@@ -1,19 +1,26 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/Brum3ns/firefly/internal/global"
 	"github.com/Brum3ns/firefly/internal/option"
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/pkg/expression"
 	"github.com/Brum3ns/firefly/pkg/extract"
 	"github.com/Brum3ns/firefly/pkg/functions"
 	"github.com/Brum3ns/firefly/pkg/httpdiff"
 	"github.com/Brum3ns/firefly/pkg/httpfilter"
 	"github.com/Brum3ns/firefly/pkg/httpprepare"
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
 	"github.com/Brum3ns/firefly/pkg/payloads"
 	"github.com/Brum3ns/firefly/pkg/randomness"
 	"github.com/Brum3ns/firefly/pkg/request"
 	"github.com/Brum3ns/firefly/pkg/transformation"
+	"golang.org/x/exp/slices"
 )
 
 type Configure struct {
@@ -22,6 +29,14 @@ type Configure struct {
 	Option     *option.Options
 	Wordlist   *payloads.Wordlist
 	Scanner    *Scanner
+	// Filter/match expressions (nil if not set)
+	FilterExpression *expression.Expression
+	MatchExpression  *expression.Expression
+	// Fuzz wordlists of hosts that have their own wordlist (host hash|wordlist)
+	HostWordlist map[string][]string
+	// Named insert points and the payload sets to use within them for each wordlist tag (tag|sets)
+	InsertPoints []insertpoint.Point
+	AttackSets   map[string][]insertpoint.Set
 }
 
 // Scanner properties (static storage)
@@ -42,6 +57,9 @@ func NewConfigure(opt *option.Options) (*Configure, error) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if opt.Unicode {
+		wl_transformation = append(wl_transformation, transformation.VariantWordlist(transformation.UnicodeVariants(payloads.DEFAULT_CHARS))...)
+	}
 
 	// Configure HTTP filter
 	filter, err := httpfilter.NewFilter(httpfilter.Config{
@@ -75,17 +93,30 @@ func NewConfigure(opt *option.Options) (*Configure, error) {
 		return &Configure{}, err
 	}
 
+	// Configure the filter/match expressions
+	filterExpression, err := compileExpression(opt.FilterExpression)
+	if err != nil {
+		return &Configure{}, errors.New("filter expression (-fx): " + err.Error())
+	}
+	matchExpression, err := compileExpression(opt.MatchExpression)
+	if err != nil {
+		return &Configure{}, errors.New("match expression (-mx): " + err.Error())
+	}
+
 	conf := &Configure{
-		Option:     opt,
-		Httpfilter: filter,
-		HttpMatch:  match,
+		Option:           opt,
+		Httpfilter:       filter,
+		HttpMatch:        match,
+		FilterExpression: filterExpression,
+		MatchExpression:  matchExpression,
 		Wordlist: payloads.NewWordlist(
 			&payloads.Wordlist{
 				Files:              opt.WordlistPaths,
 				TransformationList: wl_transformation,
 				Verify: payloads.Verify{
-					Payload: opt.VerifyPayload,
-					Amount:  opt.VerifyAmount,
+					Payload:   opt.VerifyPayload,
+					Amount:    opt.VerifyAmount,
+					Calibrate: calibrateAmount(opt.AutoCalibrate),
 				},
 				PayloadProperties: payloads.PayloadProperties{
 					Tamper:         opt.Tamper,
@@ -99,6 +130,20 @@ func NewConfigure(opt *option.Options) (*Configure, error) {
 		),
 	}
 
+	// Load the wordlists for hosts that have their own wordlist
+	conf.HostWordlist = make(map[string][]string)
+	for hash, host := range opt.Hosts {
+		if len(host.Wordlist) > 0 {
+			conf.HostWordlist[hash] = conf.Wordlist.LoadFile(host.Wordlist)
+		}
+	}
+
+	if len(opt.InsertPoints) > 0 {
+		if err := conf.makeAttackSets(); err != nil {
+			return &Configure{}, err
+		}
+	}
+
 	//Return a *pointer* of the "Scanner" [struct]ure:
 	conf.Scanner, err = conf.newScanner()
 	if err != nil {
@@ -109,10 +154,55 @@ func NewConfigure(opt *option.Options) (*Configure, error) {
 
 }
 
+// Load the wordlists of the named insert points and make the payload sets for each wordlist tag.
+// The verify payloads are given to all insert points and the transformation payloads are given to one insert point at a time (sniper).
+func (conf *Configure) makeAttackSets() error {
+	var (
+		err      error
+		wordlist = conf.Wordlist.GetAll()
+	)
+	for _, p := range conf.Option.InsertPoints {
+		p.Payloads = conf.Wordlist.LoadFile(p.Wordlist)
+		if len(p.Original) == 0 {
+			p.Original = conf.Option.VerifyPayload
+		}
+		conf.InsertPoints = append(conf.InsertPoints, p)
+	}
+
+	conf.AttackSets = make(map[string][]insertpoint.Set)
+	for _, tag := range []string{payloads.TAG_VERIFY, payloads.TAG_CALIBRATE} {
+		for _, payload := range wordlist[tag] {
+			conf.AttackSets[tag] = append(conf.AttackSets[tag], insertpoint.SameSet(conf.InsertPoints, payload))
+		}
+	}
+
+	if conf.AttackSets[payloads.TAG_FUZZ], err = insertpoint.Attack(strings.ToLower(conf.Option.Attack), conf.InsertPoints); err != nil {
+		return err
+	}
+
+	var transformationPoints []insertpoint.Point
+	for _, p := range conf.InsertPoints {
+		p.Payloads = wordlist[payloads.TAG_TRANSFORMATION]
+		transformationPoints = append(transformationPoints, p)
+	}
+	conf.AttackSets[payloads.TAG_TRANSFORMATION], err = insertpoint.Attack(insertpoint.ATTACK_SNIPER, transformationPoints)
+	return err
+}
+
 func (conf *Configure) newScanner() (*Scanner, error) {
 	//Setup scanner technique resources:
-	wlPtn, wlRegex := extract.MakeWordlists(global.DIR_DETECTION)
-	wlPatternPrefix, wlPatterns := extract.CreatePrefixMap(wlPtn)
+	// Note : (The regex are compiled and the pattern matcher is built once for all the scans)
+	rules, err := extract.LoadRules(global.DIR_DETECTION)
+	if err != nil {
+		return &Scanner{}, err
+	}
+	extractor, err := extract.NewExtract(extract.Properties{
+		Rules:   rules,
+		Context: conf.Option.Context,
+	})
+	if err != nil {
+		return &Scanner{}, err
+	}
 
 	rand, err := randomness.NewRandomness(randomness.DefaultConfig())
 	if err != nil {
@@ -124,6 +214,10 @@ func (conf *Configure) newScanner() (*Scanner, error) {
 	if err != nil {
 		return &Scanner{}, err
 	}
+	transform.Context = conf.Option.Context
+	if conf.Option.Unicode {
+		transform.AddVariants(transformation.UnicodeVariants(payloads.DEFAULT_CHARS))
+	}
 
 	return &Scanner{
 		OK_Extract:         conf.Option.Techniques["E"],
@@ -133,20 +227,42 @@ func (conf *Configure) newScanner() (*Scanner, error) {
 
 		Randomness:     rand,
 		Transformation: transform,
-		Extract: extract.NewExtract(extract.Properties{
-			Threads:         conf.Option.ThreadsExtract,
-			PrefixPatterns:  wlPatternPrefix,
-			WordlistPattern: wlPatterns,
-			WordlistRegex:   map[string][]string{extract.WILDCARD: wlRegex},
-		}),
+		Extract:        extractor,
 		HttpDiffFilter: httpdiff.Filter{
 			HeaderFilter: httpdiff.HeaderFilter{
 				Header: httpprepare.GetHeaderNode(request.LstToHeaders(LstToKeyMap(conf.Option.FilterDiffHeader))),
 			},
+			SimilarityThreshold: conf.Option.FilterSimilarity,
 		},
 	}, nil
 }
 
+// Get the amount of calibration payloads to use (zero if auto calibration is not used)
+func calibrateAmount(autoCalibrate bool) int {
+	if autoCalibrate {
+		return payloads.CALIBRATE_AMOUNT
+	}
+	return 0
+}
+
+// Compile the filter/match expression and make sure that only known fields are used within it.
+// Return nil in case no expression is given.
+func compileExpression(s string) (*expression.Expression, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, nil
+	}
+	expr, err := expression.Compile(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range expr.Fields() {
+		if !slices.Contains(httpfilter.EXPRESSION_FIELDS, field) && !slices.Contains(output.EXPRESSION_FIELDS, field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+	return expr, nil
+}
+
 func LstToKeyMap(lst []string) map[string]string {
 	var m = make(map[string]string)
 	for _, i := range lst {
@@ -14,6 +14,12 @@ var ERRORCODE_MESSAGES = map[int]string{
 	1011:   design.STATUS.FAIL + " The filter syntax is invalid. The valid for each filter separeted by a comma (if any) are as following (not combined): \".\",\"-\",\"--\",\"++\"",
 	1008:   design.STATUS.FAIL + " No input was detected (" + design.COLOR.ORANGE + "-u" + design.COLOR.WHITE + "," + design.COLOR.ORANGE + "-f" + design.COLOR.WHITE + ") or STDIN pipeline",
 	1001:   design.STATUS.FAIL + " Invalid HTTP Raw data" + design.COLOR.ORANGE + "-r" + design.COLOR.WHITE + ")",
+	1004:   design.STATUS.FAIL + " The scope file given can't be found (" + design.COLOR.ORANGE + "-scope" + design.COLOR.WHITE + ")",
+	1012:   design.STATUS.FAIL + " The HAR file given can't be found (" + design.COLOR.ORANGE + "-har" + design.COLOR.WHITE + ")",
+	1013:   design.STATUS.FAIL + " The Burp Suite XML file given can't be found (" + design.COLOR.ORANGE + "-burp" + design.COLOR.WHITE + ")",
+	1014:   design.STATUS.FAIL + " The OpenAPI file given can't be found (" + design.COLOR.ORANGE + "-openapi" + design.COLOR.WHITE + ")",
+	8008:   design.STATUS.FAIL + " The named insert points must be unique and their wordlist must exist (" + design.COLOR.ORANGE + "-ip" + design.COLOR.WHITE + ")",
+	8009:   design.STATUS.FAIL + " Invalid attack strategy (" + design.COLOR.ORANGE + "-attack" + design.COLOR.WHITE + "). Valid input: sniper, pitchfork, clusterbomb",
 	10005:  design.STATUS.FAIL + " No insert points detected (" + design.COLOR.ORANGE + "-i" + design.COLOR.WHITE + ")",
 	8001:   design.STATUS.FAIL + " The argument \"payload-replace\" (" + design.COLOR.ORANGE + "-pr" + design.COLOR.WHITE + ") do not contain the \" => \" (spaces included). Firefly dosen't know what to replace the regex/string with.",
 	1006:   design.STATUS.FAIL + " Can't use a threads lower or equal to zero (" + design.COLOR.ORANGE + "-t" + design.COLOR.WHITE + ")",
@@ -21,9 +27,17 @@ var ERRORCODE_MESSAGES = map[int]string{
 	10009:  design.STATUS.FAIL + " Invalid input for \"auto-detect\" (" + design.COLOR.ORANGE + "-au" + design.COLOR.WHITE + ")",
 	100014: design.STATUS.FAIL + " Invalid random value Example usage: s8 (string with length as 8) or 's4,n8' to use both string and number(" + design.COLOR.RED + "Random: Invalid usage" + design.COLOR.WHITE + ")",
 	13003:  design.STATUS.FAIL + " Can't setup the payload given (" + design.COLOR.ORANGE + "-verify-char" + design.COLOR.WHITE + ")",
+	13006:  design.STATUS.FAIL + " The timing threshold must be more than zero (" + design.COLOR.ORANGE + "-timing-threshold" + design.COLOR.WHITE + ")",
+	14002:  design.STATUS.FAIL + " Invalid OOB domain (" + design.COLOR.ORANGE + "-oob-domain" + design.COLOR.WHITE + "). Ex: oob.example.com",
+	14003:  design.STATUS.FAIL + " Invalid OOB HTTP server address (" + design.COLOR.ORANGE + "-oob-http" + design.COLOR.WHITE + "). The address must be {host}:{port} and can only be empty in case the DNS server is used",
+	14004:  design.STATUS.FAIL + " Invalid OOB DNS server address (" + design.COLOR.ORANGE + "-oob-dns" + design.COLOR.WHITE + "). The address must be {host}:{port} and the OOB domain must be set (" + design.COLOR.ORANGE + "-oob-domain" + design.COLOR.WHITE + ")",
+	14005:  design.STATUS.FAIL + " Invalid OOB IP address (" + design.COLOR.ORANGE + "-oob-ip" + design.COLOR.WHITE + ")",
+	14006:  design.STATUS.FAIL + " The OOB wait time can't be negative (" + design.COLOR.ORANGE + "-oob-wait" + design.COLOR.WHITE + ")",
+	5010:   design.STATUS.FAIL + " The context can't be negative (" + design.COLOR.ORANGE + "-context" + design.COLOR.WHITE + ")",
 	2001:   design.STATUS.FAIL + " The level has to be between 1-3 (" + design.COLOR.ORANGE + "-lv" + design.COLOR.WHITE + ")",
 	3001:   design.STATUS.FAIL + " The match mode is invalid (" + design.COLOR.ORANGE + "-mmode" + design.COLOR.WHITE + "). Valid input: and, or",
 	3010:   design.STATUS.FAIL + " The filter mode is invalid (" + design.COLOR.ORANGE + "-fmode" + design.COLOR.WHITE + "). Valid input: and, or",
+	3022:   design.STATUS.FAIL + " The similarity must be between 0 and 1 (" + design.COLOR.ORANGE + "-fsim" + design.COLOR.WHITE + ")",
 	9003:   design.STATUS.FAIL + " Invalid transformation input",
 	10001:  design.STATUS.FAIL + " Invalid URL(s) given (" + design.COLOR.ORANGE + "-u" + design.COLOR.WHITE + ")",
 	10002:  design.STATUS.FAIL + " Invalid method(s) given (" + design.COLOR.ORANGE + "-X" + design.COLOR.WHITE + ")",
@@ -32,6 +46,14 @@ var ERRORCODE_MESSAGES = map[int]string{
 	9002:   design.STATUS.FAIL + " Invalid wordlist folder given. Firefly coulen't find atleast one valid file (wordlist to use) in the folder. Make sure that the files in the folder are correct set and not empty (" + design.COLOR.ORANGE + "-wf" + design.COLOR.WHITE + ").",
 	10012:  design.STATUS.FAIL + " Cannot use a timeout lower than zero",
 	4001:   design.STATUS.FAIL + " The specified output file already exists. Use the overwrite option to overwrite it (be careful).",
+	4002:   design.STATUS.FAIL + " Invalid output format (" + design.COLOR.ORANGE + "-of" + design.COLOR.WHITE + ")",
+	4004:   design.STATUS.FAIL + " The results file to convert can't be found (" + design.COLOR.ORANGE + "-convert" + design.COLOR.WHITE + ")",
+	4005:   design.STATUS.FAIL + " An output file must be set to convert the results file to (" + design.COLOR.ORANGE + "-o" + design.COLOR.WHITE + ")",
+	4006:   design.STATUS.FAIL + " Invalid webhook URL, the URL must use the scheme http[s] (" + design.COLOR.ORANGE + "-webhook" + design.COLOR.WHITE + ")",
+	4007:   design.STATUS.FAIL + " Invalid webhook template (" + design.COLOR.ORANGE + "-webhook-template" + design.COLOR.WHITE + ")",
+	4008:   design.STATUS.FAIL + " The webhook batch size must be more than zero (" + design.COLOR.ORANGE + "-webhook-batch" + design.COLOR.WHITE + ")",
+	4009:   design.STATUS.FAIL + " The webhook retries can't be negative (" + design.COLOR.ORANGE + "-webhook-retry" + design.COLOR.WHITE + ")",
+	4010:   design.STATUS.FAIL + " The webhook score can't be negative (" + design.COLOR.ORANGE + "-webhook-score" + design.COLOR.WHITE + ")",
 }
 
 // Check the failed type
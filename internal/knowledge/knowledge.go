@@ -6,6 +6,7 @@ import (
 	"github.com/Brum3ns/firefly/internal/output"
 	"github.com/Brum3ns/firefly/pkg/extract"
 	"github.com/Brum3ns/firefly/pkg/httpprepare"
+	"github.com/Brum3ns/firefly/pkg/simhash"
 )
 
 type Knowledge struct {
@@ -13,6 +14,10 @@ type Knowledge struct {
 	Responses     []output.Response
 	Requests      []output.Request
 	Combine       Combine
+	// Responses of the random calibration payloads (auto calibration)
+	Calibration []output.Response
+	// Simhash of the response bodies
+	Simhash []uint64
 }
 
 type Combine struct {
@@ -27,6 +32,8 @@ type Learnt struct {
 	Extract  extract.Result
 	Response output.Response
 	Request  output.Request
+	// The result is from a calibration payload (only used to calibrate the target)
+	Calibrate bool
 }
 
 func NewKnowledge() *Knowledge {
@@ -48,9 +55,14 @@ func GetKnowledge(learnt map[string][]Learnt) map[string]Knowledge {
 	for hashId, data := range learnt {
 		k := Knowledge{}
 		for _, d := range data {
+			if d.Calibrate {
+				k.Calibration = append(k.Calibration, d.Response)
+				continue
+			}
 			k.PayloadVerify = d.Payload
 			k.Requests = append(k.Requests, d.Request)
 			k.Responses = append(k.Responses, d.Response)
+			k.Simhash = append(k.Simhash, simhash.FromHTMLNode(d.HTMLNode))
 
 			k.Combine.HeaderNode = c.HeaderNode.Merge(d.Response.Headers)
 			k.Combine.Extract = combineAppendMaps(reflect.ValueOf(&c.Extract), d.Extract).(extract.ResultCombine)
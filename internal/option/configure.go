@@ -2,12 +2,17 @@ package option
 
 import (
 	"log"
+	"net"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/Brum3ns/firefly/internal/global"
+	"github.com/Brum3ns/firefly/internal/output"
 	"github.com/Brum3ns/firefly/pkg/files"
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
+	"golang.org/x/exp/slices"
 )
 
 // The structure configure is a alias for *Options in it's current state but holds all the validation/configuration functions.
@@ -94,6 +99,35 @@ func (conf *configure) Output() bool {
 	return !files.FileExist(conf.opt.Output) || conf.opt.Overwrite
 }
 
+func (conf *configure) OutputFormat() bool {
+	return len(conf.opt.OutputFormat) == 0 || slices.Contains(output.OUTPUT_FORMATS, strings.ToLower(conf.opt.OutputFormat))
+}
+
+func (conf *configure) Webhook() bool {
+	if len(conf.opt.Webhook) == 0 {
+		return true
+	}
+	u, err := url.Parse(conf.opt.Webhook)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0
+}
+
+func (conf *configure) WebhookTemplate() bool {
+	_, err := output.WebhookTemplate(conf.opt.WebhookTemplate)
+	return err == nil
+}
+
+func (conf *configure) WebhookBatch() bool {
+	return conf.opt.WebhookBatch > 0
+}
+
+func (conf *configure) WebhookRetry() bool {
+	return conf.opt.WebhookRetry >= 0
+}
+
+func (conf *configure) WebhookScore() bool {
+	return conf.opt.WebhookScore >= 0
+}
+
 func (conf *configure) MaxIdleConns() bool {
 	return conf.opt.MaxIdleConns > 0
 }
@@ -112,12 +146,66 @@ func (conf *configure) PayloadReplace() bool {
 	return len(conf.opt.PayloadReplace) == 0 || (len(conf.opt.PayloadReplace) > 0 && strings.Contains(conf.opt.PayloadReplace, " => "))
 }
 func (conf *configure) URLs() bool {
-	return len(conf.opt.URLs) > 0
+	return len(conf.opt.URLs) > 0 || len(conf.opt.Hosts) > 0
+}
+func (conf *configure) Scope() bool {
+	return len(conf.opt.Scope) == 0 || files.FileExist(conf.opt.Scope)
+}
+func (conf *configure) HAR() bool {
+	return len(conf.opt.HAR) == 0 || files.FileExist(conf.opt.HAR)
+}
+func (conf *configure) Burp() bool {
+	return len(conf.opt.Burp) == 0 || files.FileExist(conf.opt.Burp)
+}
+func (conf *configure) OpenAPI() bool {
+	return len(conf.opt.OpenAPI) == 0 || files.FileExist(conf.opt.OpenAPI)
+}
+func (conf *configure) InsertPoints() bool {
+	var keywords []string
+	for _, p := range conf.opt.InsertPoints {
+		if slices.Contains(keywords, p.Keyword) || !files.FileExist(p.Wordlist) {
+			return false
+		}
+		keywords = append(keywords, p.Keyword)
+	}
+	return true
+}
+func (conf *configure) Attack() bool {
+	return slices.Contains(insertpoint.ATTACKS, strings.ToLower(conf.opt.Attack))
 }
 func (conf *configure) MatchMode() bool {
 	mode := strings.ToLower(conf.opt.MatchMode)
 	return mode == "or" || mode == "and"
 }
+func (conf *configure) TimingThreshold() bool {
+	return conf.opt.TimingThreshold > 0
+}
+
+func (conf *configure) OOBDomain() bool {
+	domain := strings.Trim(conf.opt.OOBDomain, ".")
+	return len(domain) == 0 || (!strings.ContainsAny(domain, "/: ") && strings.Contains(domain, "."))
+}
+func (conf *configure) OOBHTTP() bool {
+	return !conf.opt.OOB || validAddress(conf.opt.OOBHTTP) || (len(conf.opt.OOBHTTP) == 0 && len(conf.opt.OOBDNS) > 0)
+}
+func (conf *configure) OOBDNS() bool {
+	return len(conf.opt.OOBDNS) == 0 || (validAddress(conf.opt.OOBDNS) && len(conf.opt.OOBDomain) > 0)
+}
+func (conf *configure) OOBIP() bool {
+	return len(conf.opt.OOBIP) == 0 || net.ParseIP(conf.opt.OOBIP) != nil
+}
+func (conf *configure) OOBWait() bool {
+	return conf.opt.OOBWait >= 0
+}
+
+func (conf *configure) Context() bool {
+	return conf.opt.Context >= 0
+}
+
+func (conf *configure) FilterSimilarity() bool {
+	return conf.opt.FilterSimilarity >= 0 && conf.opt.FilterSimilarity <= 1
+}
+
 func (conf *configure) FilterMode() bool {
 	mode := strings.ToLower(conf.opt.FilterMode)
 	return mode == "or" || mode == "and"
@@ -153,3 +241,9 @@ func (conf *configure) VerifyAmount() bool {
 func (conf *configure) WordlistPaths() bool {
 	return len(conf.opt.wordlistPath) > 0 && len(conf.opt.WordlistPaths) > 0
 }
+
+// Check if the address is a valid bind address (Ex: ":80", "127.0.0.1:8080")
+func validAddress(address string) bool {
+	_, port, err := net.SplitHostPort(address)
+	return err == nil && len(port) > 0
+}
@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -14,13 +15,17 @@ import (
 
 	"github.com/Brum3ns/firefly/internal/fail"
 	"github.com/Brum3ns/firefly/internal/global"
+	"github.com/Brum3ns/firefly/internal/output"
 	"github.com/Brum3ns/firefly/internal/setup"
 	"github.com/Brum3ns/firefly/internal/version"
 	"github.com/Brum3ns/firefly/pkg/design"
 	"github.com/Brum3ns/firefly/pkg/files"
 	"github.com/Brum3ns/firefly/pkg/functions"
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
 	"github.com/Brum3ns/firefly/pkg/parameter"
 	"github.com/Brum3ns/firefly/pkg/request"
+	"github.com/Brum3ns/firefly/pkg/scope"
+	"github.com/Brum3ns/firefly/pkg/snippet"
 	"golang.org/x/exp/slices"
 )
 
@@ -32,6 +37,7 @@ type Options struct {
 	Input
 	Request
 	Verify
+	OutOfBand
 	Wordlist
 	Payload
 	Filter
@@ -48,6 +54,11 @@ type Input struct {
 	technique  string          `flag:"tq" errorcode:"0"` //<-local
 	Techniques map[string]bool `flag:"" errorcode:"1003"`
 	ReqRaw     string          `flag:"r" errorcode:"1001"`
+	Scope      string          `flag:"scope" errorcode:"1004"`
+	HAR        string          `flag:"har" errorcode:"1012"`
+	Burp       string          `flag:"burp" errorcode:"1013"`
+	OpenAPI    string          `flag:"openapi" errorcode:"1014"`
+	openAPIURL string          `flag:"openapi-server" errorcode:"0"` //<-local
 }
 
 // ////////////// Diff //////////////// //
@@ -77,14 +88,27 @@ type Filter struct {
 	FilterBodyRegex               string `flag:"fr" errorcode:"3016"`
 	FilterHeaderRegex             string `flag:"fh" errorcode:"3017"`
 	FilterHeader                  string `flag:"fH" errorcode:"3018"`
+	FilterExpression              string `flag:"fx" errorcode:"3020"`
+	MatchExpression               string `flag:"mx" errorcode:"3021"`
 
 	filterDiffHeader string   `flag:"fdH" errorcode:"3019"`
 	FilterDiffHeader []string `flag:"fdH" errorcode:"3019"`
+	FilterSimilarity float64  `flag:"fsim" errorcode:"3022"`
 }
 
 // ////////////// Output //////////////// //
 type File struct {
-	Output string `flag:"o" errorcode:"4001"`
+	Output       string `flag:"o" errorcode:"4001"`
+	OutputFormat string `flag:"of" errorcode:"4002"`
+	OutputBody   bool   `flag:"ob" errorcode:"4003"`
+	Convert      string `flag:"convert" errorcode:"4004"`
+	// Webhook to send the results to (in batches) while the scan is running
+	Webhook           string   `flag:"webhook" errorcode:"4006"`
+	WebhookTemplate   string   `flag:"webhook-template" errorcode:"4007"`
+	WebhookBatch      int      `flag:"webhook-batch" errorcode:"4008"`
+	WebhookRetry      int      `flag:"webhook-retry" errorcode:"4009"`
+	WebhookScore      int      `flag:"webhook-score" errorcode:"4010"`
+	WebhookTechniques []string `flag:"webhook-technique" errorcode:"4011"`
 }
 
 // ////////////// Display //////////////// //
@@ -96,6 +120,9 @@ type Display struct {
 	TerminalUI  bool `flag:"tui" errorcode:"5005"`
 	Verbose     bool `flag:"v" errorcode:"5006"`
 	Detail      bool `flag:"detail" errorcode:"5007"`
+	JSON        bool `flag:"json" errorcode:"5008"`
+	Cluster     bool `flag:"cluster" errorcode:"5009"`
+	Context     int  `flag:"context" errorcode:"5010"`
 }
 
 // ////////////// Payload //////////////// //
@@ -108,6 +135,9 @@ type Payload struct {
 	Encode         []string `flag:"e" errorcode:"8006"`
 	encode         string   `flag:"e" errorcode:"0"` //<-local
 	InsertKeyword  string   `flag:"insert" errorcode:"8007"`
+	// Named insert points (Ex: FUZZ1, FUZZ2) and the attack strategy to use them with
+	InsertPoints []insertpoint.Point `flag:"ip" errorcode:"8008"`
+	Attack       string              `flag:"attack" errorcode:"8009"`
 }
 
 // ////////////// Wordlist //////////////// //
@@ -115,6 +145,7 @@ type Wordlist struct {
 	wordlistPath           string   `flag:"w" errorcode:"9001"`
 	WordlistPaths          []string `flag:"w" errorcode:"9001"`
 	TransformationYAMLFile string   `flag:"yml-tfmt" errorcode:"9003"`
+	Unicode                bool     `flag:"unicode" errorcode:"9004"`
 	wordlistValid          bool
 }
 
@@ -139,6 +170,12 @@ type Request struct {
 	Random       map[string]int                  `flag:"" errorcode:"100014"`
 	Hosts        map[string]request.Host         `flag:"" errorcode:"10000"`
 	Params       map[string]parameter.Parameter  `flag:"" errorcode:"100016"`
+	AutoHeaders  bool                            `flag:"au-headers" errorcode:"10017"`
+
+	// Param rules of hosts given by a scope file (host hash|param rules)
+	hostRules map[string]map[string]parameter.QueryRules // <-Local
+	// Amount of targets added from the scope file and the imports (each target has its own host hash)
+	targetCount int // <-Local
 }
 
 // ////////////// Preformance //////////////// //
@@ -161,11 +198,24 @@ type General struct {
 
 // ////////////// Verify //////////////// //
 type Verify struct {
-	VerifyAmount  int    `flag:"vf" errorcode:"13001"`
-	VerifyPayload string `flag:"vP" errorcode:"13002"`
+	VerifyAmount    int     `flag:"vf" errorcode:"13001"`
+	VerifyPayload   string  `flag:"vP" errorcode:"13002"`
+	AutoCalibrate   bool    `flag:"ac" errorcode:"13004"`
+	Timing          bool    `flag:"timing" errorcode:"13005"`
+	TimingThreshold float64 `flag:"timing-threshold" errorcode:"13006"`
 	//VerifyChar    string `flag:"vC" errorcode:"13003"`
 }
 
+// ////////////// Out-of-band //////////////// //
+type OutOfBand struct {
+	OOB       bool   `flag:"oob" errorcode:"14001"`
+	OOBDomain string `flag:"oob-domain" errorcode:"14002"`
+	OOBHTTP   string `flag:"oob-http" errorcode:"14003"`
+	OOBDNS    string `flag:"oob-dns" errorcode:"14004"`
+	OOBIP     string `flag:"oob-ip" errorcode:"14005"`
+	OOBWait   int    `flag:"oob-wait" errorcode:"14006"`
+}
+
 type Randomness struct {
 	InRow     int
 	Triggers  string
@@ -178,6 +228,7 @@ type Randomness struct {
 func NewOptions() *Options {
 	opt := &Options{}
 	opt.paramRules = make(map[string]parameter.QueryRules)
+	opt.hostRules = make(map[string]map[string]parameter.QueryRules)
 	opt.Params = make(map[string]parameter.Parameter)
 	opt.Hosts = make(map[string]request.Host)
 	opt.Methods = []string{"GET"}
@@ -214,9 +265,15 @@ func NewOptions() *Options {
 	flag.Func("H", "Header(s) to include in all requests *separated by comma*, if a comma is used wihtin the header value simply escape it with a backslash (\\,)", opt.setHeaders)
 	flag.Func("X", "HTTP method(s) to use *separated by comma* (all = all methods except \"DELETE\". To add method \"DELETE\", do \"all,delete\")", opt.setMethods)
 	flag.Func("r", "HTTP Request raw data to be sent. In quotes *separated by new lines*. (Addicted of the \"scheme\" option)", opt.setRaw)
+	flag.StringVar(&opt.HAR, "har", "", "HAR (1.2) file to import. Each captured request becomes a target with its headers, body and cookies and with its parameters auto detected (unless \"-au\" is set)")
+	flag.StringVar(&opt.Burp, "burp", "", "Burp Suite \"save items\" XML file to import. Each request becomes a target with its headers, body and cookies and with its parameters auto detected (unless \"-au\" is set)")
+	flag.StringVar(&opt.OpenAPI, "openapi", "", "OpenAPI 3 or Swagger 2 document (JSON or YAML) to import. Each operation becomes a target where all the parameters and JSON body values are insert points")
+	flag.StringVar(&opt.openAPIURL, "openapi-server", "", "Base URL to use for the operations in the OpenAPI document instead of the server(s) defined in it "+exampleValues("https://api.example.com/v1"))
+	flag.StringVar(&opt.Scope, "scope", "", "Scope file (JSONL or YAML) where each target can have its own: url, method, headers, body, cookies, auto-params and wordlist "+exampleValues("scope.jsonl"))
 	flag.Func("random", `Random [s]tring / [n]umber with a digit at the end to set the length. Both can be set *separeted by a comma*. The keyword(s): "#RANDOM#" / "#RANDOMNUM#" will be replaced with a random value`, opt.setRandomInsert)
 	flag.Func("e", "Encode type to be used within the payload (order matter) *separated by a comma*. "+support_encodes(), opt.setEncode)
 	flag.Func("au", "Auto detect parameters. More than one can be added *separated by comma*. "+support_autoParameters()+". "+support_format("{param_postion}:{[r]eplace|[a]ppend}:{separators}")+"\n\t\tThe last option (separators) is optional. Note that in \"url\" the \"?\" is added by default. In case you must use \":\" as a separator escape it as \"\\:\".\n\t\t"+exampleValues("url:replace:& | body:a | body:append,url:replace:&;,cookie:replace")+"\n", opt.setAutoParamRules)
+	flag.BoolVar(&opt.AutoHeaders, "au-headers", false, "Add a list of interesting headers (Ex: X-Forwarded-For) to fuzz in the auto detected \"header\" position")
 
 	flag.StringVar(&opt.technique, "tq", "ETD", "Technique(s) to be used within the process ([D]iff, [E]xtract, [T]ransformation or [X] to disable all techniques) by letter")
 
@@ -229,9 +286,22 @@ func NewOptions() *Options {
 
 	//- [ Verify ] -
 	flag.IntVar(&opt.VerifyAmount, "vf", 10, "Verify the original behavior. The amount of verification request to be sent (Recommended amount: 5-9)")
+	flag.BoolVar(&opt.AutoCalibrate, "ac", false, "Auto calibrate a filter for each target from the verification and random calibration responses to filter the default response of the target (Ex: catch-all pages)")
+	flag.BoolVar(&opt.Timing, "timing", false, "Detect response time anomalies compared to the verification responses (Ex: blind SQLi). Suspicious payloads are re-sent (with a scaled delay, Ex: sleep(5) => sleep(10)) to be confirmed")
+	flag.Float64Var(&opt.TimingThreshold, "timing-threshold", 2, "Minimum time in seconds above the average verification response time for a response time to be suspicious")
 	flag.StringVar(&opt.VerifyPayload, "vP", "13333337", "Verification payload to be used in the process (should be a simple payload of [a-zA-Z0-9])")
 
+	//- [ OOB ] -
+	flag.BoolVar(&opt.OOB, "oob", false, "Start an out-of-band (OOB) server to detect blind behaviors (Ex: SSRF, XXE, RCE). The keyword \"#OOB#\" in the payloads or the request is replaced with a unique host for each request and all DNS/HTTP interactions with it are findings")
+	flag.StringVar(&opt.OOBDomain, "oob-domain", "", "Domain that the OOB DNS server is authoritative for. The hosts are given as a subdomain "+exampleValues("oob.example.com → {id}.oob.example.com")+". By default the hosts are given as a URL path to the OOB HTTP server")
+	flag.StringVar(&opt.OOBHTTP, "oob-http", "", "Address that the OOB HTTP server bind to (required unless the OOB DNS server is used) "+exampleValues("127.0.0.1:8080"))
+	flag.StringVar(&opt.OOBDNS, "oob-dns", "", "Address that the OOB DNS server bind to (require \"-oob-domain\") "+exampleValues(":53"))
+	flag.StringVar(&opt.OOBIP, "oob-ip", "", "Public IP of the OOB server. Used in the DNS answers and in the hosts in case no domain is set")
+	flag.IntVar(&opt.OOBWait, "oob-wait", 5, "Seconds to wait for delayed OOB interactions once all requests are done")
+
 	flag.StringVar(&opt.InsertKeyword, "insert", "FUZZ", "Payload insert point to be replaced with the payload")
+	flag.Func("ip", "Named insert point(s) with their own wordlist *separated by comma*. The original value is optional and used when the insert point is not attacked (default: the verify payload). "+support_format("{keyword}:{wordlist}[:{original value}]")+" "+exampleValues("FUZZ1:users.txt:admin,FUZZ2:passwords.txt"), opt.setInsertPoints)
+	flag.StringVar(&opt.Attack, "attack", insertpoint.ATTACK_SNIPER, "Attack strategy for the named insert points (\"-ip\"): [sniper] one insert point at a time, [pitchfork] the wordlists in parallel, [clusterbomb] all payload combinations")
 	flag.StringVar(&opt.PayloadReplace, "pr", "", "Use regex (RE2) to replace parts within the payloads. Use ( => ) as a \"replace to\" indicator. (Spaces are needed) "+exampleValues(" \"'\\([0-9]+=[0-9]+\\) => (13=(37-24))'\". Will resul in: From=\"Z'or(1=1)--+-\" To=\"Z'or(13=(37-24))--+-\""))
 	flag.StringVar(&opt.PayloadPattern, "pt", "9182", `Pattern of payload to be used. If this is set to none, it will be harder to detect payload reflected payload changes in the response(s). `+exampleValues("\"9182\" → 9182{PAYLOAD}9182"))
 	flag.StringVar(&opt.PayloadSuffix, "ps", "", "Add string to the end of the payload")
@@ -240,6 +310,7 @@ func NewOptions() *Options {
 
 	//- [ Transformation ] -
 	flag.StringVar(&opt.TransformationYAMLFile, "yml-tfmt", global.FILE_TRANSFORMATION, "Yaml file with payload transformation config")
+	flag.BoolVar(&opt.Unicode, "unicode", false, "Add the Unicode compatibility and confusable variants of the special characters to the transformation payloads. Report which variant collapsed to which ASCII character "+exampleValues("\"＜\" => \"<\""))
 
 	//- [ Match ] -
 	flag.StringVar(&opt.MatchMode, "mmode", "or", "Match mode (AND|OR)")
@@ -251,6 +322,7 @@ func NewOptions() *Options {
 	flag.StringVar(&opt.MatchBodyRegex, "mr", "", "Match body regex (RE2)")
 	flag.StringVar(&opt.MatchHeaderRegex, "mh", "", "Match header regex (RE2)")
 	flag.StringVar(&opt.MatchHeader, "mH", "", "Match headers")
+	flag.StringVar(&opt.MatchExpression, "mx", "", "Match expression. Fields: status, size, words, lines, time, body, headers, header[name], payload, url, method, extract, extract.pattern[name], extract.regex[name], extract.category[name], transformation, diff, diff.header, diff.html, similarity, timing, oob, technique, score "+exampleValues(`'status in [200,302] && (words > 50 || header["server"] ~ "nginx")'`))
 
 	//- [ Filter ] -
 	flag.StringVar(&opt.FilterMode, "fmode", "or", "Filter mode (AND|OR)")
@@ -262,15 +334,18 @@ func NewOptions() *Options {
 	flag.StringVar(&opt.FilterBodyRegex, "fr", "", "Filter body regex (RE2)")
 	flag.StringVar(&opt.FilterHeaderRegex, "fh", "", "Filter header regex (RE2)")
 	flag.StringVar(&opt.FilterHeader, "fH", "", "Filter headers")
+	flag.StringVar(&opt.FilterExpression, "fx", "", "Filter expression (same fields as the match expression) "+exampleValues(`'!body ~ "not found" && extract == 0'`))
 
 	//- [ FIlter diff ] -
 	flag.StringVar(&opt.filterDiffHeader, "fdH", global.FILE_SKIP_HEADERS, "Headers to ignore if they are a difference in the HTTP response separated by comma or as a wordlist file (if you want to keep the default wordlist, you can add the keyword 'DEFAULT')")
 
+	flag.Float64Var(&opt.FilterSimilarity, "fsim", 0, "Ignore the HTML difference in case the response body is as similar or more similar (0-1) to the known responses (Ex: tiny dynamic changes) "+exampleValues("0.95"))
+
 	//- [ Preformance ] -
 	flag.IntVar(&opt.Timeout, "timeout", 11, "Timeout in secounds before giving up on the response")
 	flag.IntVar(&opt.Threads, "t", 50, "Threads (requests)")
 	flag.IntVar(&opt.ThreadsScanner, "tS", 3, "Number of processes to be run in the scanner (this can take up a lot of CPU usage if the value is too high)")
-	flag.IntVar(&opt.ThreadsExtract, "tE", 2, "Threads to be used to extract patterns from target response data (hardware)")
+	flag.IntVar(&opt.ThreadsExtract, "tE", 2, "Deprecated: the patterns are extracted within the scanner threads (\"-tS\")")
 	flag.IntVar(&opt.Delay, "delay", 0, "Delay in milliseconds (ms) between each request each thread")
 	flag.IntVar(&opt.MaxIdleConns, "idle", 1000, "Controls the maximum number of idle (keep-alive) connections across all hosts")
 	flag.IntVar(&opt.MaxIdleConnsPerHost, "idle-host", 500, "Controls the maximum idle (keep-alive) connections to keep per-host")
@@ -280,6 +355,9 @@ func NewOptions() *Options {
 	/*In development*/ //flag.BoolVar(&opt.TerminalUI, "tui", false, "Use advanced terminal user interface (UI)")
 	flag.BoolVar(&opt.Detail, "detail", false, "Show the difference discovered in an unexpected behavior")
 	flag.BoolVar(&opt.NoDisplay, "no-display", false, "Do not display result to screen")
+	flag.BoolVar(&opt.Cluster, "cluster", false, "Group similar results (same target, insert point and detection techniques) and only display the first result of each group. When used with -convert only one result of each group is converted")
+	flag.IntVar(&opt.Context, "context", snippet.WINDOW, "Amount of characters of context to keep on each side of the extract matches and the transformed payloads (shown with \"-detail\" and in the output file). Zero to not keep any context")
+	flag.BoolVar(&opt.JSON, "json", false, "Print one JSON object per result to stdout (all other output is sent to stderr) "+exampleValues("-json | jq"))
 	flag.BoolVar(&opt.ShowConfig, "show-config", false, "Display all configured parses and their values before the process starts")
 
 	//- [ Randomness ] -
@@ -296,7 +374,16 @@ func NewOptions() *Options {
 	flag.StringVar(&opt.wordlistPath, "w", global.DIR_WORDLIST, "Wordlist to be used. A single wordlist can be selected or a folder containing wordlists (files must have an \"txt\" extension if a folder is used) "+exampleValues("\"/path/to/wordlist.txt\""))
 
 	//- [ Output ] -
-	flag.StringVar(&opt.Output, "o", "", "Output result to given file (JSON format)")
+	flag.StringVar(&opt.Output, "o", "", "Output result to given file (JSON format by default)")
+	flag.StringVar(&opt.OutputFormat, "of", "", "Output format to use. By default the format is detected by the output file extension. "+support_format(strings.Join(output.OUTPUT_FORMATS, ",")))
+	flag.BoolVar(&opt.OutputBody, "ob", false, "Include the response body in the output file")
+	flag.StringVar(&opt.Convert, "convert", "", "Convert a results file (JSON or JSON Lines) to the output file and format (Ex: HTML report) then exit "+exampleValues("-convert result.json -o report.html"))
+	flag.StringVar(&opt.Webhook, "webhook", "", "Webhook URL to send the results to while the scan is running (Ex: Slack, Discord or a custom HTTP server)")
+	flag.StringVar(&opt.WebhookTemplate, "webhook-template", "", "Template of the webhook payload (Go template). A file, the template itself or a built-in template can be used. By default the results are sent as a JSON array. "+support_format("slack,discord"))
+	flag.IntVar(&opt.WebhookBatch, "webhook-batch", 1, "Amount of results to send in each webhook request (the pending results are sent at least every 10 seconds)")
+	flag.IntVar(&opt.WebhookRetry, "webhook-retry", 3, "Amount of retries in case a webhook request failed")
+	flag.IntVar(&opt.WebhookScore, "webhook-score", 0, "Minimum score (total hits of the techniques that detected the behavior) of the results to send to the webhook")
+	flag.Func("webhook-technique", "Only send the results detected by the technique(s) to the webhook *separated by comma* "+exampleValues("extract,diff/header,transformation"), opt.setWebhookTechniques)
 	flag.BoolVar(&opt.Overwrite, "overwrite", false, "Overwrite the existing file name to be used as the output file (use carefully)")
 
 	//- [ Update ] -
@@ -319,6 +406,11 @@ func NewOptions() *Options {
 			fmt.Println(stout)
 		}
 		os.Exit(0)
+	case len(opt.Convert) > 0:
+		if err := opt.convert(); err != nil {
+			log.Fatal(design.STATUS.ERROR, " Convert: ", err)
+		}
+		os.Exit(0)
 	}
 
 	//Set Option values that wasen't possible in the flag process direcly:
@@ -336,17 +428,24 @@ func NewOptions() *Options {
 		log.Fatal(err)
 	}
 
+	opt.Headers = append(opt.Headers, [2]string{"user-agent", opt.UserAgent})
+
 	//Setup the parameter object for each supported position within the HTTP request
 	if err := opt.makeURLs(); err != nil {
 		log.Fatal(err)
 	}
+	//Setup the targets given in the scope file and imported requests (if any)
+	if err := opt.makeScope(); err != nil {
+		log.Fatal(design.STATUS.ERROR, " Scope: ", err)
+	}
+	if err := opt.makeImport(); err != nil {
+		log.Fatal(design.STATUS.ERROR, " Import: ", err)
+	}
 	//Setup all params in related to their host and supported position within the HTTP request
 	if err := opt.makeParams(); err != nil {
 		log.Fatal(err)
 	}
 
-	opt.Headers = append(opt.Headers, [2]string{"user-agent", opt.UserAgent})
-
 	if err := opt.makeWordlist(); err != nil {
 		log.Fatal(design.STATUS.ERROR, err)
 	}
@@ -365,6 +464,26 @@ func NewOptions() *Options {
 	return configuredOptions
 }
 
+// Convert the results file to the output file and format (if set)
+func (opt *Options) convert() error {
+	switch {
+	case !files.FileExist(opt.Convert):
+		fail.IFFail(4004)
+	case len(opt.Output) == 0:
+		fail.IFFail(4005)
+	case files.FileExist(opt.Output) && !opt.Overwrite:
+		fail.IFFail(4001)
+	case len(opt.OutputFormat) > 0 && !slices.Contains(output.OUTPUT_FORMATS, strings.ToLower(opt.OutputFormat)):
+		fail.IFFail(4002)
+	}
+	amount, err := output.Convert(opt.Convert, opt.Output, opt.OutputFormat, opt.Cluster)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Converted %d results to: %s\n", design.STATUS.OK, amount, opt.Output)
+	return nil
+}
+
 // Read stdin and add the given input to the 'Options struct' (if any)
 func (opt *Options) readStdin() error {
 	if data, err := os.Stdin.Stat(); err != nil {
@@ -457,6 +576,127 @@ func (opt *Options) makeURLs() error {
 	return nil
 }
 
+// Add all targets from the scope file (if set). Each target is added as its own host with its own request properties and parameter rules.
+func (opt *Options) makeScope() error {
+	if len(opt.Scope) == 0 {
+		return nil
+	}
+	targets, err := scope.ReadFile(opt.Scope)
+	if err != nil {
+		return err
+	}
+	return opt.addTargets(targets)
+}
+
+// Import the requests from HAR, Burp Suite XML and/or OpenAPI files (if set).
+func (opt *Options) makeImport() error {
+	var importers = []struct {
+		file string
+		read func(string) ([]scope.Target, error)
+		// Auto detect the parameters of the imported requests (unless "-au" is set)
+		autoParams bool
+	}{
+		{file: opt.HAR, read: scope.ReadHAR, autoParams: true},
+		{file: opt.Burp, read: scope.ReadBurp, autoParams: true},
+		{file: opt.OpenAPI, read: func(file string) ([]scope.Target, error) {
+			return scope.ReadOpenAPI(file, opt.InsertKeyword, opt.openAPIURL)
+		}},
+	}
+	for _, i := range importers {
+		if len(i.file) == 0 {
+			continue
+		}
+		targets, err := i.read(i.file)
+		if err != nil {
+			return fmt.Errorf("%s: %s", i.file, err)
+		}
+		if i.autoParams && len(opt.paramRules) == 0 {
+			for idx := range targets {
+				targets[idx].AutoParams = scope.AutoParams(targets[idx], "replace")
+			}
+		}
+		if err := opt.addTargets(targets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add targets as hosts. Values that are not set in the target will be taken from the global options.
+func (opt *Options) addTargets(targets []scope.Target) error {
+	for _, t := range targets {
+		idx := opt.targetCount
+		opt.targetCount++
+		var (
+			schemes = opt.Scheme
+			methods = opt.Methods
+			u       = t.URL
+		)
+		if scheme := request.ContainScheme(u); scheme != "" {
+			schemes = []string{scheme}
+			u = strings.Replace(u, (scheme + "://"), "", 1)
+		}
+		if len(t.Method) > 0 {
+			methods = []string{t.Method}
+		}
+
+		// Target rules replace the global rules (-au) for the target:
+		var (
+			rules = opt.paramRules
+			err   error
+		)
+		if len(t.AutoParams) > 0 {
+			if rules, err = makeAutoParamRules(t.AutoParams, opt.InsertKeyword); err != nil {
+				return fmt.Errorf("target %s: %s", t.URL, err)
+			}
+		}
+
+		if len(t.Wordlist) > 0 {
+			if fSize, err := files.FileSize(t.Wordlist); err != nil || fSize == 0 {
+				return fmt.Errorf("target %s: the wordlist \"%s\" is missing or empty", t.URL, t.Wordlist)
+			}
+		}
+
+		for _, scheme := range schemes {
+			for _, method := range methods {
+				hash := request.MakeHash(fmt.Sprintf("%s#%d", u, idx), method)
+
+				opt.Hosts[hash] = request.Host{
+					URL:      (scheme + "://" + u),
+					Method:   method,
+					Scheme:   scheme,
+					Headers:  MergeHeaders(opt.Headers, t.Headers, t.CookieHeader()),
+					PostData: t.Body,
+					Wordlist: t.Wordlist,
+				}
+				opt.hostRules[hash] = rules
+			}
+		}
+	}
+	return nil
+}
+
+// Merge the global headers with the target headers. A target header will replace a global header with the same name.
+// The cookie value is added to the cookie header (if any).
+func MergeHeaders(global, target [][2]string, cookie string) [][2]string {
+	var headers [][2]string
+	for _, h := range global {
+		if name, _ := request.GetHeader(target, h[0]); name == "" {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers, target...)
+
+	if len(cookie) > 0 {
+		if name, value := request.GetHeader(headers, "cookie"); name != "" {
+			headers = request.SetNewHeaderValue(headers, "cookie", strings.TrimSpace(value)+"; "+cookie)
+		} else {
+			headers = append(headers, [2]string{"cookie", cookie})
+		}
+	}
+	return headers
+}
+
 func (opt *Options) makeFilterDiffHeader() error {
 	for _, i := range functions.SplitEscape(opt.filterDiffHeader, ',') {
 		// Detected that the default wordlist should be included too
@@ -634,32 +874,76 @@ func (opt *Options) setTechniques() error {
 }
 
 // Detect and add insert points within the URLs GET parameters.
+// Note : (Hosts from a scope file use their own headers, post data and rules if they are set)
 func (opt *Options) makeParams() error {
-	_, cookieQuery := request.GetHeader(opt.Headers, "cookie")
-
 	for hash, host := range opt.Hosts {
+		var (
+			rules    = opt.paramRules
+			headers  = opt.Headers
+			postData = opt.PostData
+		)
+		if r, ok := opt.hostRules[hash]; ok {
+			rules = r
+		}
+		if len(host.Headers) > 0 {
+			headers = host.Headers
+		}
+		if len(host.PostData) > 0 {
+			postData = host.PostData
+		}
+		_, cookieQuery := request.GetHeader(headers, "cookie")
+		_, contentType := request.GetHeader(headers, "content-type")
+
 		//Add parameters to the related URL hash:
-		parameter, err := parameter.NewParameter(opt.paramRules, opt.InsertKeyword)
+		parameter, err := parameter.NewParameter(rules, opt.InsertKeyword)
 		if err != nil {
 			return err
 		}
-		for position, _ := range opt.paramRules {
+		for position := range rules {
 
 			//Set the params in relation to their position for each host:
 			switch position {
 			case "url":
-				if URLQuery, err := request.GetRawQuery(host.URL); len(URLQuery) > 0 && err == nil {
-					parameter.SetURLparams(URLQuery)
-				} else {
+				URLQuery, err := request.GetRawQuery(host.URL)
+				if err != nil {
 					return err
 				}
+				if len(URLQuery) > 0 {
+					parameter.SetURLparams(URLQuery)
+				}
 			case "body":
-				if len(opt.PostData) > 0 {
-					parameter.SetBodyparams(opt.PostData)
+				if len(postData) > 0 {
+					parameter.SetBodyparams(postData)
 				}
 			case "cookie":
 				if len(cookieQuery) > 0 {
-					parameter.SetCookieparams(cookieQuery)
+					parameter.SetCookieparams(strings.TrimSpace(cookieQuery))
+				}
+			case "json":
+				if len(postData) > 0 {
+					if err := parameter.SetJSONparams(postData); err != nil {
+						return fmt.Errorf("%s Can't auto detect the JSON parameters of %s: %s", design.STATUS.ERROR, host.URL, err)
+					}
+				}
+			case "xml":
+				if len(postData) > 0 {
+					if err := parameter.SetXMLparams(postData); err != nil {
+						return fmt.Errorf("%s Can't auto detect the XML parameters of %s: %s", design.STATUS.ERROR, host.URL, err)
+					}
+				}
+			case "multipart":
+				if len(postData) > 0 {
+					if err := parameter.SetMultipartparams(postData, contentType); err != nil {
+						return fmt.Errorf("%s Can't auto detect the multipart parameters of %s: %s", design.STATUS.ERROR, host.URL, err)
+					}
+				}
+			case "header":
+				if len(headers) > 0 || opt.AutoHeaders {
+					parameter.SetHeaderparams(headers, opt.AutoHeaders)
+				}
+			case "path":
+				if u, err := url.Parse(host.URL); err == nil && len(strings.Trim(u.EscapedPath(), "/")) > 0 {
+					parameter.SetPathparams(u.EscapedPath())
 				}
 			default:
 				return errors.New("no valid position find for auto params")
@@ -673,9 +957,22 @@ func (opt *Options) makeParams() error {
 }
 
 func (opt *Options) setAutoParamRules(s string) error {
-	var err error
+	rules, err := makeAutoParamRules(s, opt.InsertKeyword)
+	if err != nil {
+		log.Fatal(design.STATUS.FAIL, " ", err, ", Option: (\033[33m-au\033[0m)")
+	}
+	for position, rule := range rules {
+		opt.paramRules[position] = rule
+	}
+	return nil
+}
+
+// Make the auto parameter rules from the format: "{param_postion}:{[r]eplace|[a]ppend}:{separators}" *separated by comma*
+func makeAutoParamRules(s, insertKeyword string) (map[string]parameter.QueryRules, error) {
+	var rules = make(map[string]parameter.QueryRules)
+
 	for _, paramRule := range functions.SplitEscape(s, ',') {
-		paramRule = strings.ToLower(paramRule)
+		paramRule = strings.ToLower(strings.TrimSpace(paramRule))
 
 		if lst := functions.SplitEscape(paramRule, ':'); len(lst) == 2 || len(lst) == 3 {
 			var ( //Extracted part of definitions from the core input value:
@@ -691,21 +988,48 @@ func (opt *Options) setAutoParamRules(s string) error {
 			}
 			//Validate and set rules in relation to the param position specified:
 			//Note : Validate each defined value (separators are optional and can be anything):
-			if ok, _ := regexp.MatchString(`^(replace|append|r|a)$`, method); !ok { //Method validation
-				log.Fatal(design.STATUS.FAIL, " Invalid method used in auto detection of parameters, supported \"replace\" or \"append\" (r/a), Option: (\033[33m-au\033[0m)")
+			switch method { //Method validation
+			case "r":
+				method = "replace"
+			case "a":
+				method = "append"
 			}
-
-			if ok, _ := regexp.MatchString(`^(url|body|cookie)$`, position); !ok { //Postion validation
-				log.Fatal(design.STATUS.FAIL, " Invalid postion used in auto detection of parameters, supported \"url\", \"body\" or \"cookie\" (r/a), Option: (\033[33m-au\033[0m)")
+			if !slices.Contains(parameter.SUPPORTED_PARAM_METHODS, method) {
+				return rules, errors.New("invalid method used in auto detection of parameters, supported \"replace\" or \"append\" (r/a)")
 			}
-			//Set the auto parameter value for the option [struct]ure:
-			opt.paramRules[position], err = parameter.NewRules(opt.InsertKeyword, method, separators)
+			if !slices.Contains(parameter.SUPPORTED_PARAM_POSITIONS, position) { //Postion validation
+				return rules, fmt.Errorf("invalid postion used in auto detection of parameters, supported \"%s\"", strings.Join(parameter.SUPPORTED_PARAM_POSITIONS, "\", \""))
+			}
+			//Set the auto parameter value for the position:
+			rule, err := parameter.NewRules(insertKeyword, method, separators)
 			if err != nil {
-				return err
+				return rules, err
 			}
+			rules[position] = rule
 
 		} else {
-			log.Fatal(design.STATUS.FAIL, " Syntax error on auto detection of parameter(s), Option: (\033[33m-au\033[0m)")
+			return rules, errors.New("syntax error on auto detection of parameter(s)")
+		}
+	}
+	return rules, nil
+}
+
+// Set the named insert points "{keyword}:{wordlist}[:{original value}]" (can be used multiple times)
+func (opt *Options) setInsertPoints(s string) error {
+	for _, i := range functions.SplitEscape(s, ',') {
+		p, err := insertpoint.ParsePoint(i)
+		if err != nil {
+			return err
+		}
+		opt.InsertPoints = append(opt.InsertPoints, p)
+	}
+	return nil
+}
+
+func (opt *Options) setWebhookTechniques(s string) error {
+	for _, i := range strings.Split(s, ",") {
+		if i = strings.TrimSpace(i); len(i) > 0 {
+			opt.WebhookTechniques = append(opt.WebhookTechniques, i)
 		}
 	}
 	return nil
@@ -737,7 +1061,7 @@ func (opt *Options) setRandomInsert(s string) error {
 }
 
 func (opt *Options) showConfigOnScreen() {
-	fmt.Println(strings.Repeat("_", 64), "\n\r")
+	fmt.Fprintln(os.Stderr, strings.Repeat("_", 64), "\n\r")
 	flag.VisitAll(func(f *flag.Flag) {
 		var (
 			lenName = len(fmt.Sprintf("%v", f.Value))
@@ -750,10 +1074,10 @@ func (opt *Options) showConfigOnScreen() {
 			} else {
 				value = fmt.Sprintf("%v", f.Value)
 			}
-			fmt.Printf(" - %s :: %s\n", strings.Title(f.Name), value)
+			fmt.Fprintf(os.Stderr, " - %s :: %s\n", strings.Title(f.Name), value)
 		}
 	})
-	fmt.Println(strings.Repeat("_", 64), "\n\r")
+	fmt.Fprintln(os.Stderr, strings.Repeat("_", 64), "\n\r")
 }
 
 // Display the supported encoders that can be used to payloads
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Maximum amount of example payloads stored for each cluster
+var CLUSTER_PAYLOAD_EXAMPLES = 5
+
+// Information about the cluster of similar results that a result represent
+type ClusterInfo struct {
+	Signature string   `json:"Signature"`
+	Count     int      `json:"Count"`
+	Payloads  []string `json:"Payloads"`
+}
+
+// A cluster is a group of results with the same target, insert point and techniques that detected the behavior.
+// The first result of the cluster is used as the representative of all the results within it.
+type Cluster struct {
+	Result ResultFinal
+	ClusterInfo
+}
+
+// The clusters group the results by their signature (see: ClusterSignature)
+type Clusters struct {
+	index    map[string]int
+	clusters []*Cluster
+	mutex    sync.Mutex
+}
+
+func NewClusters() *Clusters {
+	return &Clusters{index: make(map[string]int)}
+}
+
+// Make the signature of the result from the target, the request method, the URL and insert points that the payload was inserted into and the techniques that detected the behavior
+func ClusterSignature(result ResultFinal) string {
+	var (
+		points     []string
+		techniques []string
+	)
+	for keyword := range result.InsertPoints {
+		points = append(points, keyword)
+	}
+	sort.Strings(points)
+	for _, t := range Techniques(result) {
+		techniques = append(techniques, t.Id)
+	}
+	sort.Strings(techniques)
+
+	return strings.Join([]string{
+		result.TargetHashId,
+		result.Request.Method,
+		result.Request.URLOriginal,
+		strings.Join(points, ","),
+		strings.Join(techniques, ","),
+	}, "|")
+}
+
+// Add the result to its cluster (a new cluster is made if it's the first result of its kind).
+// Return the amount of results within the cluster after the result was added.
+func (c *Clusters) Add(result ResultFinal) int {
+	signature := ClusterSignature(result)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	idx, ok := c.index[signature]
+	if !ok {
+		idx = len(c.clusters)
+		c.index[signature] = idx
+		c.clusters = append(c.clusters, &Cluster{
+			Result:      result,
+			ClusterInfo: ClusterInfo{Signature: signature},
+		})
+	}
+	cluster := c.clusters[idx]
+	cluster.Count++
+	if len(cluster.Payloads) < CLUSTER_PAYLOAD_EXAMPLES && !containsString(cluster.Payloads, result.Payload) {
+		cluster.Payloads = append(cluster.Payloads, result.Payload)
+	}
+	return cluster.Count
+}
+
+// Get all the clusters in the order they were first seen
+func (c *Clusters) List() []Cluster {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var lst []Cluster
+	for _, cluster := range c.clusters {
+		i := *cluster
+		i.Payloads = append([]string{}, cluster.Payloads...)
+		lst = append(lst, i)
+	}
+	return lst
+}
+
+// Group the results into clusters and return one representative result for each cluster.
+// The cluster information (count and example payloads) is added to the representative result.
+func ClusterResults(results []ResultFinal) []ResultFinal {
+	c := NewClusters()
+	for _, result := range results {
+		c.Add(result)
+	}
+	var lst []ResultFinal
+	for _, cluster := range c.List() {
+		result := cluster.Result
+		info := cluster.ClusterInfo
+		result.Cluster = &info
+		lst = append(lst, result)
+	}
+	return lst
+}
+
+func containsString(lst []string, s string) bool {
+	for _, i := range lst {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
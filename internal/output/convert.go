@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// Convert a results file (JSON or JSON Lines) to the given output format (Ex: HTML report).
+// In case "cluster" is true, only one representative result of each cluster of similar results is converted.
+// Return the amount of results that were converted.
+// Note : (The response body is kept in case the results file include it)
+func Convert(input, path, format string, cluster bool) (int, error) {
+	results, err := ReadResults(input)
+	if err != nil {
+		return 0, err
+	}
+	if cluster {
+		results = ClusterResults(results)
+	}
+	w, err := NewWriter(path, format, true)
+	if err != nil {
+		return 0, err
+	}
+	for _, result := range results {
+		// Note : (The "OK" status is not stored in the results file)
+		result.OK = true
+		if err := w.Write(result); err != nil {
+			w.Close()
+			return 0, err
+		}
+	}
+	return len(results), w.Close()
+}
+
+// Read all the results from a results file. The file can be a JSON array or JSON Lines.
+func ReadResults(file string) ([]ResultFinal, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+
+	var results []ResultFinal
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &results); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var result ResultFinal
+		if err := decoder.Decode(&result); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.New("invalid results file (JSON or JSON Lines): " + err.Error())
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{
+	"RequestId", "TargetId", "Tag", "Method", "URL", "Payload",
+	"Status", "ContentLength", "WordCount", "LineCount", "Time",
+	"ExtractPatternBody", "ExtractPatternHeaders", "ExtractRegexBody", "ExtractRegexHeaders",
+	"DiffHeader", "DiffTag", "DiffAttribute", "DiffAttributeValue", "DiffWords", "DiffComment",
+	"Transformation",
+}
+
+// Make a CSV record (row) of the result. The extract columns hold the total hits and the diff columns the amount of differences.
+func csvRecord(result ResultFinal) []string {
+	var (
+		extract = result.Scanner.Extract
+		appear  = result.Scanner.Diff.HTMLResult.Appear
+		tfmt    = result.Scanner.Transformation
+		desc    string
+	)
+	if tfmt.OK {
+		desc = fmt.Sprintf("%s (%s => %s)", tfmt.Desc, tfmt.Payload, tfmt.Format)
+	}
+	record := []string{
+		strconv.Itoa(result.RequestId),
+		result.TargetHashId,
+		result.Tag,
+		result.Request.Method,
+		result.Request.URL,
+		result.Payload,
+		strconv.Itoa(result.Response.StatusCode),
+		strconv.Itoa(result.Response.ContentLength),
+		strconv.Itoa(result.Response.WordCount),
+		strconv.Itoa(result.Response.LineCount),
+		strconv.FormatFloat(result.Response.Time, 'f', 3, 64),
+		strconv.Itoa(sumHits(extract.PatternBody)),
+		strconv.Itoa(sumHits(extract.PatternHeaders)),
+		strconv.Itoa(sumHits(extract.RegexBody)),
+		strconv.Itoa(sumHits(extract.RegexHeaders)),
+		strconv.Itoa(result.Scanner.Diff.HeaderResult.HeaderHits),
+		strconv.Itoa(appear.TagStartHits + appear.TagEndHits + appear.TagSelfCloseHits),
+		strconv.Itoa(appear.AttributeHits),
+		strconv.Itoa(appear.AttributeValueHits),
+		strconv.Itoa(appear.WordsHits),
+		strconv.Itoa(appear.CommentHits),
+		desc,
+	}
+	for idx, i := range record {
+		record[idx] = csvSafe(i)
+	}
+	return record
+}
+
+func sumHits(m map[string]int) int {
+	var n int
+	for _, i := range m {
+		n += i
+	}
+	return n
+}
+
+// Prevent values (Ex: payloads) from being interpreted as formulas when the CSV file is opened in a spreadsheet.
+func csvSafe(s string) string {
+	if len(s) > 0 && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"github.com/Brum3ns/firefly/pkg/expression"
+	"github.com/Brum3ns/firefly/pkg/extract"
+	"github.com/Brum3ns/firefly/pkg/httpfilter"
+	"github.com/Brum3ns/firefly/pkg/transformation"
+)
+
+// Fields of the result (request and scanner) that can be used within a filter/match expression in addition to the HTTP response fields.
+// Note : (Expressions that use these fields can only be evaluated once the response is scanned)
+var EXPRESSION_FIELDS = []string{
+	"payload", "tag", "url", "method",
+	"extract", "extract.pattern", "extract.regex", "extract.category",
+	"transformation", "transformation.payload", "transformation.format", "transformation.desc", "transformation.steps",
+	"diff", "diff.header", "diff.html", "similarity",
+	"timing", "oob", "technique", "score",
+}
+
+// Make the expression environment of the result (including the HTTP response fields)
+func Env(result ResultFinal) expression.Env {
+	var (
+		extract = result.Scanner.Extract
+		appear  = result.Scanner.Diff.HTMLResult.Appear
+		tfmt    = result.Scanner.Transformation
+		html    = appear.TagStartHits + appear.TagEndHits + appear.TagSelfCloseHits + appear.AttributeHits + appear.AttributeValueHits + appear.WordsHits + appear.CommentHits
+		header  = result.Scanner.Diff.HeaderResult.HeaderHits
+	)
+	env := httpfilter.Response{
+		Body:         []byte(result.Response.Body),
+		StatusCode:   result.Response.StatusCode,
+		ResponseSize: result.Response.ContentLength,
+		WordCount:    result.Response.WordCount,
+		LineCount:    result.Response.LineCount,
+		ResponseTime: result.Response.Time,
+		Headers:      result.Response.Headers,
+	}.Env()
+
+	var techniques []string
+	for _, t := range Techniques(result) {
+		techniques = append(techniques, t.Id)
+	}
+
+	env["payload"] = result.Payload
+	env["tag"] = result.Tag
+	env["url"] = result.Request.URL
+	env["method"] = result.Request.Method
+	env["extract"] = sumHits(extract.PatternBody) + sumHits(extract.PatternHeaders) + sumHits(extract.RegexBody) + sumHits(extract.RegexHeaders)
+	env["extract.pattern"] = mergeHits(extract.PatternBody, extract.PatternHeaders)
+	env["extract.regex"] = mergeHits(extract.RegexBody, extract.RegexHeaders)
+	env["extract.category"] = categoryHits(extract)
+	env["transformation"] = tfmt.OK
+	env["transformation.payload"] = tfmt.Payload
+	env["transformation.format"] = tfmt.Format
+	env["transformation.desc"] = tfmt.Desc
+	env["transformation.steps"] = transformationSteps(tfmt.Steps)
+	env["diff"] = header + html
+	env["diff.header"] = header
+	env["diff.html"] = html
+	env["similarity"] = result.Scanner.Diff.Similarity
+	env["timing"] = result.Scanner.Timing.OK
+	env["oob"] = len(result.Scanner.OOB) > 0
+	env["technique"] = techniques
+	env["score"] = Score(result)
+	return env
+}
+
+// Get the hits of each rule category (category|hits)
+func categoryHits(e extract.Result) map[string]int {
+	m := make(map[string]int)
+	for item, hits := range mergeHits(e.PatternBody, e.PatternHeaders, e.RegexBody, e.RegexHeaders) {
+		if rule, ok := e.Rules[item]; ok {
+			m[rule.Category] += hits
+		}
+	}
+	return m
+}
+
+// Get the categories of the transformation steps in the order they were applied
+func transformationSteps(steps []transformation.Step) []string {
+	lst := []string{}
+	for _, s := range steps {
+		lst = append(lst, s.Category)
+	}
+	return lst
+}
+
+func mergeHits(maps ...map[string]int) map[string]int {
+	m := make(map[string]int)
+	for _, i := range maps {
+		for k, v := range i {
+			m[k] += v
+		}
+	}
+	return m
+}
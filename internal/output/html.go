@@ -0,0 +1,307 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Brum3ns/firefly/internal/version"
+	"github.com/Brum3ns/firefly/pkg/httpprepare"
+)
+
+type htmlReport struct {
+	Date       string
+	Version    string
+	Summary    Summary
+	Findings   int
+	Techniques []htmlCount
+	Targets    []htmlTarget
+}
+
+type htmlCount struct {
+	Name  string
+	Count int
+}
+
+// Findings of a target grouped by the technique that detected the behavior
+type htmlTarget struct {
+	Id     string
+	URL    string
+	Count  int
+	Groups []htmlGroup
+}
+
+type htmlGroup struct {
+	Technique Technique
+	Findings  []htmlFinding
+}
+
+type htmlFinding struct {
+	ResultFinal
+	Techniques  []Technique
+	RawRequest  template.HTML
+	RawResponse template.HTML
+	HeaderDiff  [2][]string
+	HTMLDiff    [2][]string
+	Snippets    []string
+}
+
+// Render a self-contained HTML report of the results.
+// Each finding is grouped by its target and the first technique that detected the behavior.
+func renderHTML(results []ResultFinal, summary Summary) ([]byte, error) {
+	var (
+		report = htmlReport{
+			Date:     time.Now().Format(time.RFC1123),
+			Version:  version.VERSION,
+			Summary:  summary,
+			Findings: len(results),
+		}
+		targets    = make(map[string]*htmlTarget)
+		techniques = make(map[string]int)
+	)
+
+	for _, result := range results {
+		t, ok := targets[result.TargetHashId]
+		if !ok {
+			t = &htmlTarget{Id: result.TargetHashId, URL: result.Request.URLOriginal}
+			targets[result.TargetHashId] = t
+		}
+		finding := newHTMLFinding(result)
+		for _, technique := range finding.Techniques {
+			techniques[technique.Id]++
+		}
+
+		var group *htmlGroup
+		for idx := range t.Groups {
+			if t.Groups[idx].Technique.Id == finding.Techniques[0].Id {
+				group = &t.Groups[idx]
+			}
+		}
+		if group == nil {
+			t.Groups = append(t.Groups, htmlGroup{Technique: finding.Techniques[0]})
+			group = &t.Groups[len(t.Groups)-1]
+		}
+		group.Findings = append(group.Findings, finding)
+		t.Count++
+	}
+
+	for _, t := range targets {
+		sort.Slice(t.Groups, func(i, j int) bool { return t.Groups[i].Technique.Id < t.Groups[j].Technique.Id })
+		for _, g := range t.Groups {
+			sort.Slice(g.Findings, func(i, j int) bool { return g.Findings[i].RequestId < g.Findings[j].RequestId })
+		}
+		report.Targets = append(report.Targets, *t)
+	}
+	sort.Slice(report.Targets, func(i, j int) bool { return report.Targets[i].URL < report.Targets[j].URL })
+
+	for name, count := range techniques {
+		report.Techniques = append(report.Techniques, htmlCount{Name: name, Count: count})
+	}
+	sort.Slice(report.Techniques, func(i, j int) bool { return report.Techniques[i].Name < report.Techniques[j].Name })
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, report); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newHTMLFinding(result ResultFinal) htmlFinding {
+	var (
+		diff     = result.Scanner.Diff
+		payloads = highlightPayloads(result)
+	)
+	return htmlFinding{
+		ResultFinal: result,
+		Techniques:  Techniques(result),
+		RawRequest:  highlight(result.Request.Raw(), payloads),
+		RawResponse: highlight(result.Response.Raw(), payloads),
+		HeaderDiff: [2][]string{
+			headerDiffLst(diff.HeaderResult.Appear),
+			headerDiffLst(diff.HeaderResult.Disappear),
+		},
+		HTMLDiff: [2][]string{
+			htmlDiffLst(diff.HTMLResult.Appear.HTMLNode),
+			htmlDiffLst(diff.HTMLResult.Disappear.HTMLNode),
+		},
+		Snippets: Snippets(result.Scanner),
+	}
+}
+
+// Get the payloads to highlight (including the URL encoded version of the payloads)
+func highlightPayloads(result ResultFinal) []string {
+	var lst []string
+	add := func(s string) {
+		for _, i := range []string{s, url.QueryEscape(s), url.PathEscape(s)} {
+			if len(i) > 0 && !containString(lst, i) {
+				lst = append(lst, i)
+			}
+		}
+	}
+	add(result.Payload)
+	for _, payload := range result.InsertPoints {
+		add(payload)
+	}
+	// The longest payloads are replaced first:
+	sort.Slice(lst, func(i, j int) bool { return len(lst[i]) > len(lst[j]) })
+	return lst
+}
+
+// Escape the raw text and highlight all the payloads within it
+func highlight(raw string, payloads []string) template.HTML {
+	var oldnew []string
+	for _, payload := range payloads {
+		escaped := html.EscapeString(payload)
+		oldnew = append(oldnew, escaped, "<mark>"+escaped+"</mark>")
+	}
+	return template.HTML(strings.NewReplacer(oldnew...).Replace(html.EscapeString(raw)))
+}
+
+func headerDiffLst(header httpprepare.Header) []string {
+	var lst []string
+	for name, info := range header {
+		for idx, value := range info.Values {
+			amount := 1
+			if idx < len(info.Amount) {
+				amount = info.Amount[idx]
+			}
+			lst = append(lst, fmt.Sprintf("%s: %s (%d)", name, value, amount))
+		}
+	}
+	sort.Strings(lst)
+	return lst
+}
+
+func htmlDiffLst(node httpprepare.HTMLNode) []string {
+	var lst []string
+	for _, i := range []struct {
+		name string
+		m    map[string]int
+	}{
+		{"Tag-start", node.TagStart},
+		{"Tag-end", node.TagEnd},
+		{"Tag-selfclose", node.TagSelfClose},
+		{"Attribute", node.Attribute},
+		{"AttributeValue", node.AttributeValue},
+		{"Word", node.Words},
+		{"Comment", node.Comment},
+	} {
+		for _, key := range sortedKeys(i.m) {
+			lst = append(lst, fmt.Sprintf("%s: %s (%d)", i.name, key, i.m[key]))
+		}
+	}
+	return lst
+}
+
+func containString(lst []string, s string) bool {
+	for _, i := range lst {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Firefly report</title>
+<style>
+body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;margin:0;background:#f5f5f3;color:#222}
+header{background:#1d1d1d;color:#D9DCCF;padding:18px 32px}
+header h1{margin:0;font-size:22px;color:#FFDF00}
+main{padding:16px 32px}
+section{background:#fff;border:1px solid #ddd;border-radius:6px;margin-bottom:18px;padding:12px 18px}
+h2{font-size:18px;margin:6px 0 12px}
+h3{font-size:15px;margin:14px 0 6px;color:#D98D00}
+table.summary td{padding:2px 18px 2px 0}
+.badge{display:inline-block;background:#383838;color:#fff;border-radius:4px;padding:1px 6px;margin:1px 2px;font-size:12px}
+details{border-top:1px solid #eee;padding:6px 0}
+summary{cursor:pointer}
+.cols{display:grid;grid-template-columns:1fr 1fr;gap:12px}
+pre{background:#1d1d1d;color:#D9DCCF;padding:10px;overflow:auto;max-height:480px;white-space:pre-wrap;word-break:break-all;font-size:12px;margin:4px 0}
+mark{background:#EB2D3A;color:#fff;padding:0 1px}
+.muted{color:#888}
+.appear{color:#3AF191}
+.disappear{color:#EB2D3A}
+</style>
+</head>
+<body>
+<header>
+<h1>Firefly report</h1>
+<div>{{.Date}} - Firefly {{.Version}}</div>
+</header>
+<main>
+<section>
+<h2>Summary</h2>
+<table class="summary">
+<tr><td>Findings</td><td><b>{{.Findings}}</b></td></tr>
+<tr><td>Targets</td><td>{{len .Targets}}</td></tr>
+{{- with .Summary}}{{if .Statistics}}
+<tr><td>Requests / Responses</td><td>{{.Requests}} / {{.Responses}}</td></tr>
+<tr><td>Scanned</td><td>{{.Scanned}}</td></tr>
+<tr><td>Behaviors</td><td>{{.Behaviors}}</td></tr>
+<tr><td>Filtered</td><td>{{.Filtered}}</td></tr>
+<tr><td>Errors</td><td>{{.Errors}}</td></tr>
+<tr><td>Time</td><td>{{.Time}}</td></tr>
+{{- end}}{{end}}
+</table>
+{{- if .Techniques}}
+<h3>Techniques</h3>
+{{range .Techniques}}<span class="badge">{{.Name}} ({{.Count}})</span>{{end}}
+{{- end}}
+</section>
+{{range .Targets}}
+<section>
+<h2>{{.URL}} <span class="muted">({{.Count}})</span></h2>
+{{- range .Groups}}
+<h3>{{.Technique.Id}}</h3>
+{{- range .Findings}}
+<details>
+<summary>#{{.RequestId}} <b>{{.Payload}}</b> - Status: {{.Response.StatusCode}}, Words: {{.Response.WordCount}}, Lines: {{.Response.LineCount}}, CL: {{.Response.ContentLength}} - {{.Request.Method}} {{.Request.URL}}</summary>
+<p>{{range .Techniques}}<span class="badge" title="{{.Id}}">{{.Desc}}</span>{{end}}</p>
+<div class="cols">
+<div><b>Request</b><pre>{{.RawRequest}}</pre></div>
+<div><b>Response</b><pre>{{.RawResponse}}</pre></div>
+</div>
+{{- if .Snippets}}
+<b>Context</b>
+<pre>{{range .Snippets}}{{.}}
+{{end}}</pre>
+{{- end}}
+{{- if or (index .HeaderDiff 0) (index .HeaderDiff 1)}}
+<b>Header difference</b>
+<div class="cols">
+<div><span class="appear">Appear</span><pre>{{range index .HeaderDiff 0}}{{.}}
+{{end}}</pre></div>
+<div><span class="disappear">Disappear</span><pre>{{range index .HeaderDiff 1}}{{.}}
+{{end}}</pre></div>
+</div>
+{{- end}}
+{{- if or (index .HTMLDiff 0) (index .HTMLDiff 1)}}
+<b>HTML difference</b>
+<div class="cols">
+<div><span class="appear">Appear</span><pre>{{range index .HTMLDiff 0}}{{.}}
+{{end}}</pre></div>
+<div><span class="disappear">Disappear</span><pre>{{range index .HTMLDiff 1}}{{.}}
+{{end}}</pre></div>
+</div>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+</section>
+{{else}}
+<section><p class="muted">No findings</p></section>
+{{end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+var markdownTitle = "# Firefly findings\n\n"
+
+// Make a Markdown section of the result with a curl command to reproduce the request and a summary of the differences.
+func markdownFinding(result ResultFinal) string {
+	var (
+		b    strings.Builder
+		diff = result.Scanner.Diff
+	)
+	fmt.Fprintf(&b, "## #%d %s %s\n\n", result.RequestId, result.Request.Method, markdownInline(result.Request.URL))
+
+	fmt.Fprintf(&b, "| Payload | Tag | Status | Content-Length | Words | Lines | Time |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %d | %.3fs |\n\n",
+		markdownInline(result.Payload),
+		result.Tag,
+		result.Response.StatusCode,
+		result.Response.ContentLength,
+		result.Response.WordCount,
+		result.Response.LineCount,
+		result.Response.Time,
+	)
+
+	if result.Cluster != nil && result.Cluster.Count > 1 {
+		fmt.Fprintf(&b, "**Similar results** %d (payloads: %s)\n\n", result.Cluster.Count, markdownInline(strings.Join(result.Cluster.Payloads, ", ")))
+	}
+
+	b.WriteString("**Detected by**\n\n")
+	for _, t := range Techniques(result) {
+		fmt.Fprintf(&b, "- `%s` %s\n", t.Id, t.Desc)
+	}
+
+	if snippets := Snippets(result.Scanner); len(snippets) > 0 {
+		b.WriteString("\n**Context**\n\n```\n" + strings.Join(snippets, "\n") + "\n```\n")
+	}
+
+	b.WriteString("\n**Reproduce**\n\n```bash\n" + Curl(result.Request) + "\n```\n\n")
+
+	var (
+		headerDiff = [2][]string{headerDiffLst(diff.HeaderResult.Appear), headerDiffLst(diff.HeaderResult.Disappear)}
+		htmlDiff   = [2][]string{htmlDiffLst(diff.HTMLResult.Appear.HTMLNode), htmlDiffLst(diff.HTMLResult.Disappear.HTMLNode)}
+	)
+	if len(headerDiff[0])+len(headerDiff[1])+len(htmlDiff[0])+len(htmlDiff[1]) > 0 {
+		b.WriteString("**Difference**\n\n```diff\n")
+		for _, lst := range [][2][]string{headerDiff, htmlDiff} {
+			for _, i := range lst[0] {
+				b.WriteString("+ " + i + "\n")
+			}
+			for _, i := range lst[1] {
+				b.WriteString("- " + i + "\n")
+			}
+		}
+		b.WriteString("```\n\n")
+	}
+	return b.String()
+}
+
+// Make a curl command that reproduce the request
+func Curl(r Request) string {
+	var lst = []string{"curl", "-i", "-s", "-k", "-X", r.Method, shellQuote(r.URL)}
+	for _, h := range r.Headers {
+		lst = append(lst, "-H", shellQuote(h[0]+": "+strings.TrimSpace(h[1])))
+	}
+	if len(r.PostBody) > 0 {
+		lst = append(lst, "--data-raw", shellQuote(r.PostBody))
+	}
+	return strings.Join(lst, " ")
+}
+
+// Quote a string to be used as a single argument in a shell (single quotes within the string are escaped)
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Escape a value to be used inline within Markdown (Ex: in a table cell)
+func markdownInline(s string) string {
+	s = strings.NewReplacer("\r", "", "\n", " ").Replace(s)
+	if strings.Contains(s, "`") {
+		return "``" + strings.ReplaceAll(s, "|", `\|`) + "``"
+	}
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
@@ -1,47 +1,264 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 )
 
-var mutex sync.Mutex
+// Supported output formats:
+var (
+	FORMAT_JSON     = "json"
+	FORMAT_JSONL    = "jsonl"
+	FORMAT_SARIF    = "sarif"
+	FORMAT_HTML     = "html"
+	FORMAT_MARKDOWN = "md"
+	FORMAT_CSV      = "csv"
+	OUTPUT_FORMATS  = []string{FORMAT_JSON, FORMAT_JSONL, FORMAT_SARIF, FORMAT_HTML, FORMAT_MARKDOWN, FORMAT_CSV}
+)
 
 var (
-	prefix   = []byte("[\r\n")
-	suffix   = []byte("\r\n]")
-	filesize = 0
+	prefix    = []byte("[\n")
+	suffix    = []byte("\n]\n")
+	separator = []byte(",\n")
 )
 
-func WriteJSON(count int, f *os.File, result ResultFinal) error {
+// The writer stream the results to a temporary ".part" file that is valid after each write.
+// When the writer is closed the temporary file is renamed to the final output file.
+type Writer struct {
+	file   *os.File
+	path   string
+	format string
+	body   bool
+	count  int
+	closed bool
+	mutex  sync.Mutex
+
+	// Formats that are a single document (Ex: SARIF, HTML) are stored and written once the writer is closed
+	sarif   *sarifLog
+	results []ResultFinal
+	summary Summary
+}
+
+// Create an output writer of the given format (if the format is empty, the format is detected by the file extension).
+// The response body is only included in case "body" is true.
+func NewWriter(path, format string, body bool) (*Writer, error) {
+	if len(format) == 0 {
+		format = FormatFromFile(path)
+	}
+	format = strings.ToLower(format)
+	if !validFormat(format) {
+		return nil, errors.New("unsupported output format: " + format)
+	}
+	file, err := os.OpenFile(path+".part", os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		file:   file,
+		path:   path,
+		format: format,
+		body:   body,
+		sarif:  newSARIF(),
+	}, nil
+}
+
+// Get the output format from the file extension (Ex: "result.jsonl"). The default format is JSON.
+func FormatFromFile(path string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if validFormat(ext) {
+		return ext
+	}
+	return FORMAT_JSON
+}
+
+func validFormat(format string) bool {
+	for _, i := range OUTPUT_FORMATS {
+		if i == format {
+			return true
+		}
+	}
+	return false
+}
+
+// The output file is created by the writer itself (see: NewWriter)
+func (w *Writer) Open() error {
+	return nil
+}
+
+// Write a result to the output file. Results that are not OK are ignored.
+func (w *Writer) Write(result ResultFinal) error {
 	if !result.OK {
 		return nil
 	}
-	if count == 0 {
-		f.Write(prefix)
-		filesize += len(prefix)
+	if !w.body {
+		result.Response.Body = ""
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return errors.New("the output writer is closed")
 	}
 
-	dataJSON, err := json.MarshalIndent(result, "", "  ")
+	var err error
+	switch w.format {
+	case FORMAT_JSONL:
+		err = w.writeJSONL(result)
+	case FORMAT_SARIF:
+		w.sarif.add(result)
+	case FORMAT_HTML:
+		w.results = append(w.results, result)
+	case FORMAT_MARKDOWN:
+		err = w.writeMarkdown(result)
+	case FORMAT_CSV:
+		err = w.writeCSV(result)
+	default:
+		err = w.writeJSON(result)
+	}
 	if err != nil {
 		return err
 	}
+	w.count++
+	return nil
+}
 
-	f.Write(dataJSON)
+// Write the result as an item in a JSON array.
+// Note : (The end of the array is overwritten by the next result to keep the file valid after each write)
+func (w *Writer) writeJSON(result ResultFinal) error {
+	data, err := json.MarshalIndent(result, "  ", "  ")
 	if err != nil {
 		return err
 	}
+	var buf []byte
+	if w.count == 0 {
+		buf = append(buf, prefix...)
+	} else {
+		if _, err := w.file.Seek(-int64(len(suffix)), io.SeekEnd); err != nil {
+			return err
+		}
+		buf = append(buf, separator...)
+	}
+	buf = append(buf, "  "...)
+	buf = append(buf, data...)
+	buf = append(buf, suffix...)
 
-	//Replace last binary characters and append the new JSON data to output file:
-	f.Write(suffix)
+	_, err = w.file.Write(buf)
+	return err
+}
 
-	filesize += len(dataJSON)
-	filesize += len(suffix)
+// Write the result as a single line (JSON Lines)
+func (w *Writer) writeJSONL(result ResultFinal) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = w.file.Write(append(data, '\n'))
+	return err
+}
 
-	info, _ := f.Stat()
-	fmt.Println("size:", info.Size(), "|", filesize)
+// Append the result as a Markdown section (the title is written before the first result)
+func (w *Writer) writeMarkdown(result ResultFinal) error {
+	s := markdownFinding(result)
+	if w.count == 0 {
+		s = markdownTitle + s
+	}
+	_, err := w.file.WriteString(s)
+	return err
+}
 
-	return nil
+// Append the result as a CSV row (the header row is written before the first result)
+func (w *Writer) writeCSV(result ResultFinal) error {
+	writer := csv.NewWriter(w.file)
+	if w.count == 0 {
+		writer.Write(csvHeader)
+	}
+	writer.Write(csvRecord(result))
+	writer.Flush()
+	return writer.Error()
+}
+
+// Rewrite the whole output file with the given data
+func (w *Writer) rewrite(data []byte) error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := w.file.Write(data)
+	return err
+}
+
+func (w *Writer) rewriteJSON(document any) error {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.rewrite(append(data, '\n'))
+}
+
+func (w *Writer) rewriteHTML() error {
+	data, err := renderHTML(w.results, w.summary)
+	if err != nil {
+		return err
+	}
+	return w.rewrite(data)
+}
+
+// Set the summary of the run to be used by the reports (Ex: HTML)
+func (w *Writer) SetSummary(summary Summary) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.summary = summary
+}
+
+// Get the amount of results written
+func (w *Writer) Count() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.count
+}
+
+// Finalize the output file and rename it from the temporary ".part" file to the output file.
+func (w *Writer) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	// An empty document is written in case no result was written and the single document formats are written with all the results:
+	var err error
+	switch {
+	case w.count == 0 && w.format == FORMAT_JSON:
+		_, err = w.file.Write([]byte("[]\n"))
+	case w.format == FORMAT_SARIF:
+		err = w.rewriteJSON(w.sarif)
+	case w.count == 0 && w.format == FORMAT_MARKDOWN:
+		_, err = w.file.WriteString(markdownTitle + "No findings\n")
+	case w.count == 0 && w.format == FORMAT_CSV:
+		writer := csv.NewWriter(w.file)
+		writer.Write(csvHeader)
+		writer.Flush()
+		err = writer.Error()
+	case w.format == FORMAT_HTML:
+		err = w.rewriteHTML()
+	}
+	if err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.file.Name(), w.path)
 }
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Make a raw HTTP request from the request result (Ex: "GET /?q=1 HTTP/1.1")
+func (r Request) Raw() string {
+	var (
+		b    strings.Builder
+		path = r.URL
+	)
+	if u, err := url.Parse(r.URL); err == nil {
+		path = u.RequestURI()
+	}
+	b.WriteString(r.Method + " " + path + " " + proto(r.Proto) + "\r\n")
+	b.WriteString("Host: " + r.Host + "\r\n")
+	for _, h := range r.Headers {
+		b.WriteString(h[0] + ": " + strings.TrimSpace(h[1]) + "\r\n")
+	}
+	b.WriteString("\r\n")
+	b.WriteString(r.PostBody)
+	return b.String()
+}
+
+// Make a raw HTTP response from the response result (Ex: "HTTP/1.1 200 OK")
+// Note : (The body is only included in case it's stored in the result)
+func (r Response) Raw() string {
+	var (
+		b     strings.Builder
+		names []string
+	)
+	b.WriteString(proto(r.Proto) + " " + strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode) + "\r\n")
+	for name := range r.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range r.Headers[name] {
+			b.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+	b.WriteString("\r\n")
+	b.WriteString(r.Body)
+	return b.String()
+}
+
+func proto(s string) string {
+	if len(s) == 0 {
+		return "HTTP/1.1"
+	}
+	return s
+}
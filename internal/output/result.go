@@ -1,27 +1,34 @@
 package output
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/Brum3ns/firefly/pkg/extract"
 	"github.com/Brum3ns/firefly/pkg/httpdiff"
+	"github.com/Brum3ns/firefly/pkg/oob"
+	"github.com/Brum3ns/firefly/pkg/timing"
 	"github.com/Brum3ns/firefly/pkg/transformation"
 )
 
 // The output result is the final result that is generated that stores all the result from all processes done by the runner
 // Note : (The final result only stores the result details that are of int `json:""`erest to the user and not the properties that were used during the runner process. The variable may be reformulated for better readability)
 type ResultFinal struct {
-	RequestId      int      `json:"RequestId"`
-	TargetHashId   string   `json:"TargetId"`
-	Tag            string   `json:"Tag"`
-	Date           string   `json:"Date"`
-	Payload        string   `json:"Payload"`
-	Request        Request  `json:"Request"`
-	Response       Response `json:"Response"`
-	Scanner        Scanner  `json:"Scanner"`
-	Error          error    `json:"Error"`
-	OK             bool     `json:"-"`
+	RequestId    int    `json:"RequestId"`
+	TargetHashId string `json:"TargetId"`
+	Tag          string `json:"Tag"`
+	Date         string `json:"Date"`
+	Payload      string `json:"Payload"`
+	// Named insert points and the payload inserted within them (keyword|payload)
+	InsertPoints   map[string]string `json:"InsertPoints,omitempty"`
+	Request        Request           `json:"Request"`
+	Response       Response          `json:"Response"`
+	Scanner        Scanner           `json:"Scanner"`
+	Error          error             `json:"Error"`
+	OK             bool              `json:"-"`
 	UnkownBehavior bool
+	// Similar results that the result represent (only set when the results are clustered)
+	Cluster *ClusterInfo `json:"Cluster,omitempty"`
 	//Origin       string   `json:"Origin"`
 	//Behavior     Behavior `json:"Behavior"`
 }
@@ -45,6 +52,9 @@ type Request struct {
 	PostBody    string      `json:"PostBody"`
 	Proto       string      `json:"HTTP"`
 	Headers     [][2]string `json:"Headers"`
+	// The post body and headers before the payload was inserted (used to re-send the request with another payload)
+	PostBodyOriginal string      `json:"-"`
+	HeadersOriginal  [][2]string `json:"-"`
 }
 
 // Refer to the results of the request/Response process
@@ -56,7 +66,7 @@ type Response struct {
 	ContentLength int         `json:"Content-Length"`
 	ContentType   string      `json:"Contnet-Type"`
 	Host          string      `json:"Host"`
-	Body          string      `json:"-"`
+	Body          string      `json:"Body,omitempty"`
 	Title         string      `json:"Title"`
 	Proto         string      `json:"HTTP"`
 	IPAddress     []string    `json:"IPAddress"`
@@ -69,4 +79,21 @@ type Scanner struct {
 	Extract        extract.Result        `json:"Extract"`
 	Diff           httpdiff.Result       `json:"Diff"`
 	Transformation transformation.Result `json:"Transformation"`
+	Timing         timing.Result         `json:"Timing"`
+	// Out-of-band interactions with the unique host(s) given to the request
+	OOB []oob.Interaction `json:"OOB,omitempty"`
+}
+
+// Get the context snippets of the extract matches and the transformed payload in one line each (Ex: `[body:120] "SQL syntax": ...error in your »SQL syntax« near...`)
+func Snippets(s Scanner) []string {
+	var lst []string
+	for _, item := range sortedKeys(mergeHits(s.Extract.PatternBody, s.Extract.PatternHeaders, s.Extract.RegexBody, s.Extract.RegexHeaders)) {
+		for _, i := range s.Extract.Context[item] {
+			lst = append(lst, fmt.Sprintf("[%s:%d] %q: %s", i.Source, i.Start, item, i))
+		}
+	}
+	if c := s.Transformation.Context; s.Transformation.OK && c != nil {
+		lst = append(lst, fmt.Sprintf("[%s:%d] %q => %q: %s", c.Source, c.Start, s.Transformation.Payload, s.Transformation.Format, *c))
+	}
+	return lst
 }
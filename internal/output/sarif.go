@@ -0,0 +1,222 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Brum3ns/firefly/internal/version"
+	"github.com/Brum3ns/firefly/pkg/extract"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	SARIF_VERSION = "2.1.0"
+	SARIF_SCHEMA  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIF 2.1.0 log (only the properties used by Firefly)
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool      sarifTool       `json:"tool"`
+	Artifacts []sarifArtifact `json:"artifacts,omitempty"`
+	Results   []sarifResult   `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string               `json:"id"`
+	Name             string               `json:"name"`
+	ShortDescription sarifMessage         `json:"shortDescription"`
+	Properties       *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifRuleProperties struct {
+	Tags     []string `json:"tags,omitempty"`
+	Severity string   `json:"severity,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId      string            `json:"ruleId"`
+	RuleIndex   int               `json:"ruleIndex"`
+	Level       string            `json:"level"`
+	Message     sarifMessage      `json:"message"`
+	Locations   []sarifLocation   `json:"locations"`
+	Attachments []sarifAttachment `json:"attachments"`
+	Properties  sarifProperties   `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri   string `json:"uri"`
+	Index *int   `json:"index,omitempty"`
+}
+
+type sarifAttachment struct {
+	Description      sarifMessage          `json:"description"`
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+	MimeType string                `json:"mimeType"`
+	Contents sarifMessage          `json:"contents"`
+}
+
+type sarifProperties struct {
+	RequestId    int               `json:"requestId"`
+	TargetId     string            `json:"targetId"`
+	Tag          string            `json:"tag"`
+	Payload      string            `json:"payload"`
+	InsertPoints map[string]string `json:"insertPoints,omitempty"`
+	StatusCode   int               `json:"statusCode"`
+	Rules        []string          `json:"rules"`
+}
+
+func newSARIF() *sarifLog {
+	return &sarifLog{
+		Schema:  SARIF_SCHEMA,
+		Version: SARIF_VERSION,
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "Firefly",
+					Version:        strings.TrimPrefix(version.VERSION, "v"),
+					InformationUri: "https://github.com/Brum3ns/firefly",
+					Rules:          []sarifRule{},
+				},
+			},
+			Results: []sarifResult{},
+		}},
+	}
+}
+
+// Add the result to the SARIF log. The rule of the result is the first technique that detected the behavior while all the techniques are listed in the properties.
+// The raw request and response are added as attachments (embedded artifacts).
+func (s *sarifLog) add(result ResultFinal) {
+	var (
+		run        = &s.Runs[0]
+		techniques = Techniques(result)
+		ruleIds    []string
+		id         = strconv.Itoa(result.RequestId)
+	)
+	for _, t := range techniques {
+		s.rule(t)
+		ruleIds = append(ruleIds, t.Id)
+	}
+
+	var messages []string
+	for _, t := range techniques {
+		messages = append(messages, t.Desc)
+	}
+
+	r := sarifResult{
+		RuleId:    techniques[0].Id,
+		RuleIndex: s.rule(techniques[0]),
+		Level:     sarifLevel(techniques),
+		Message:   sarifMessage{Text: "Unknown behavior detected with the payload \"" + result.Payload + "\". " + strings.Join(messages, ". ")},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{Uri: result.Request.URL},
+			},
+		}},
+		Properties: sarifProperties{
+			RequestId:    result.RequestId,
+			TargetId:     result.TargetHashId,
+			Tag:          result.Tag,
+			Payload:      result.Payload,
+			InsertPoints: result.InsertPoints,
+			StatusCode:   result.Response.StatusCode,
+			Rules:        ruleIds,
+		},
+	}
+
+	for _, a := range []struct {
+		desc, uri, raw string
+	}{
+		{"HTTP request", "request-" + id + ".http", result.Request.Raw()},
+		{"HTTP response", "response-" + id + ".http", result.Response.Raw()},
+	} {
+		index := len(run.Artifacts)
+		location := sarifArtifactLocation{Uri: a.uri, Index: &index}
+		run.Artifacts = append(run.Artifacts, sarifArtifact{
+			Location: sarifArtifactLocation{Uri: a.uri},
+			MimeType: "message/http",
+			Contents: sarifMessage{Text: a.raw},
+		})
+		r.Attachments = append(r.Attachments, sarifAttachment{
+			Description:      sarifMessage{Text: a.desc},
+			ArtifactLocation: location,
+		})
+	}
+	run.Results = append(run.Results, r)
+}
+
+// Add the technique as a rule (if it's not already added) and return the index of the rule
+func (s *sarifLog) rule(t Technique) int {
+	driver := &s.Runs[0].Tool.Driver
+	for idx, rule := range driver.Rules {
+		if rule.Id == t.Id {
+			return idx
+		}
+	}
+	rule := sarifRule{
+		Id:               t.Id,
+		Name:             t.Name,
+		ShortDescription: sarifMessage{Text: t.Name + " (" + t.Id + ")"},
+	}
+	if len(t.Severity) > 0 {
+		rule.Properties = &sarifRuleProperties{Severity: t.Severity}
+		for _, tag := range []string{t.Category, t.CWE} {
+			if len(tag) > 0 {
+				rule.Properties.Tags = append(rule.Properties.Tags, tag)
+			}
+		}
+	}
+	driver.Rules = append(driver.Rules, rule)
+	return len(driver.Rules) - 1
+}
+
+// Get the SARIF level of the result from the highest severity of the detection rules (default: warning)
+func sarifLevel(techniques []Technique) string {
+	highest := -1
+	for _, t := range techniques {
+		if i := slices.Index(extract.SEVERITIES, t.Severity); i > highest {
+			highest = i
+		}
+	}
+	switch {
+	case highest < 0:
+		return "warning"
+	case highest >= slices.Index(extract.SEVERITIES, "high"):
+		return "error"
+	case highest == slices.Index(extract.SEVERITIES, "medium"):
+		return "warning"
+	}
+	return "note"
+}
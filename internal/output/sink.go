@@ -0,0 +1,115 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Brum3ns/firefly/pkg/statistics"
+)
+
+// A sink is a destination of the results (Ex: output file, webhook, screen)
+type Sink interface {
+	Open() error
+	Write(result ResultFinal) error
+	Close() error
+}
+
+// Sinks that show the progress of the run (Ex: terminal UI) are given the statistics each time they are updated
+type StatisticSink interface {
+	Statistic(stats statistics.Statistic)
+}
+
+// Sinks that include a summary of the run (Ex: HTML report) are given the summary before they are closed
+type SummarySink interface {
+	SetSummary(summary Summary)
+}
+
+// Filter of the results to give to a sink. A nil filter accept all results.
+type SinkFilter func(result ResultFinal) bool
+
+// The sink registry hold all the active sinks and give each result to the sinks that accept it
+type Sinks struct {
+	entries []sinkEntry
+}
+
+type sinkEntry struct {
+	name   string
+	sink   Sink
+	filter SinkFilter
+}
+
+func NewSinks() *Sinks {
+	return &Sinks{}
+}
+
+// Register a sink with its own filter
+// Note : (The sinks are opened, written to and closed in the order they are registered)
+func (s *Sinks) Register(name string, sink Sink, filter SinkFilter) {
+	s.entries = append(s.entries, sinkEntry{name: name, sink: sink, filter: filter})
+}
+
+// Get the amount of registered sinks
+func (s *Sinks) Len() int {
+	return len(s.entries)
+}
+
+// Open all the sinks. In case a sink fails to open, the sinks already opened are closed.
+func (s *Sinks) Open() error {
+	for i, e := range s.entries {
+		if err := e.sink.Open(); err != nil {
+			for _, opened := range s.entries[:i] {
+				opened.sink.Close()
+			}
+			return fmt.Errorf("%s: %w", e.name, err)
+		}
+	}
+	return nil
+}
+
+// Write the result to all the sinks that accept it. Return the amount of sinks the result was written to.
+func (s *Sinks) Write(result ResultFinal) (int, error) {
+	var (
+		count int
+		errs  []error
+	)
+	for _, e := range s.entries {
+		if e.filter != nil && !e.filter(result) {
+			continue
+		}
+		if err := e.sink.Write(result); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
+
+// Give the statistics to the sinks that show the progress of the run
+func (s *Sinks) Statistic(stats statistics.Statistic) {
+	for _, e := range s.entries {
+		if sink, ok := e.sink.(StatisticSink); ok {
+			sink.Statistic(stats)
+		}
+	}
+}
+
+// Give the summary of the run to the sinks that include it
+func (s *Sinks) SetSummary(summary Summary) {
+	for _, e := range s.entries {
+		if sink, ok := e.sink.(SummarySink); ok {
+			sink.SetSummary(summary)
+		}
+	}
+}
+
+// Close all the sinks. All sinks are closed even if one of them fails.
+func (s *Sinks) Close() error {
+	var errs []error
+	for _, e := range s.entries {
+		if err := e.sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -48,8 +49,8 @@ func (d Display) MakeStyles() {
 }
 
 // Display the information to the screen from a given structure (result data) to the command line interface (CLI) [show: on/off]) and any struct that *include JSON supported tags*.
-// The function use color highlighting in the CLI by using a mixture of stderr and stout. The output values will be in stout
-// version which makes it possible to support pipelining without including garbage in the values.
+// The function use color highlighting in the CLI and is meant to be read by humans.
+// Note : (Use the JSON stream (-json) to get pipeline friendly results on stdout)
 func (d *Display) ToScreen(result ResultFinal) {
 	d.ResultFinal = result
 	//print("\033[?25l")
@@ -64,7 +65,7 @@ func (d *Display) ToScreen(result ResultFinal) {
 	stout := fmt.Sprintf("%s╭ \033[33m%s\033[0m Status:%s, Words:%s, Lines:%s, CL:%s, CT:%s, Time:%sms\n"+
 		"%sErrors:[Body:%s, Header:%s] Diff:[Tag:%s, Attr:%s, AttrVal:%s, Words:%s, Comments:%s, Header:%s] %s\n",
 		TERMINAL_CLEAR,
-		d.Payload,
+		d.payload(),
 		// Response information
 		d.design.StatusCode(d.Response.StatusCode),
 		d.design.WordCount(d.Response.WordCount),
@@ -90,7 +91,7 @@ func (d *Display) ToScreen(result ResultFinal) {
 		d.design.Highlight(diff.HTMLResult.Appear.CommentHits),
 		//Difference - Headers:
 		d.design.Highlight(diff.HeaderResult.HeaderHits),
-		d.transformation(),
+		d.transformation()+d.timing()+d.oob(),
 	)
 
 	if d.detailed {
@@ -101,10 +102,52 @@ func (d *Display) ToScreen(result ResultFinal) {
 			"\n├╴[HTML]\n" +
 			d.getDetailDiff("Appear", strings.Join(htmlNodeToLst(prefix, diff.HTMLResult.Appear.HTMLNode), "\n")) +
 			d.getDetailDiff("Disappear", strings.Join(htmlNodeToLst(prefix, diff.HTMLResult.Disappear.HTMLNode), "\n"))
+
+		if snippets := Snippets(d.Scanner); len(snippets) > 0 {
+			stout += "\n├╴[Context]\n" + d.getDetailDiff("Matches", prefix+strings.Join(snippets, "\n"+prefix))
+		}
 	}
 	fmt.Println(stout)
 }
 
+// Display the clusters of similar results (only the clusters that contain more than one result are shown)
+func (d *Display) ClustersToScreen(clusters []Cluster) {
+	var lst []string
+	for _, c := range clusters {
+		if c.Count <= 1 {
+			continue
+		}
+		var ids []string
+		for _, t := range Techniques(c.Result) {
+			ids = append(ids, t.Id)
+		}
+		lst = append(lst, fmt.Sprintf("├╴ \033[33m%d\033[0m similar results to #%d %s %s (%s)\n│  Payloads: %s",
+			c.Count,
+			c.Result.RequestId,
+			c.Result.Request.Method,
+			c.Result.Request.URL,
+			strings.Join(ids, ", "),
+			strconv.Quote(strings.Join(c.Payloads, " ")),
+		))
+	}
+	if len(lst) > 0 {
+		fmt.Printf("%s╭ Clusters:\n%s\n", TERMINAL_CLEAR, strings.Join(lst, "\n"))
+	}
+}
+
+// Get the payload to display. In case named insert points were used, all the insert points and their payload are shown (Ex: FUZZ1=a FUZZ2=b)
+func (d *Display) payload() string {
+	if len(d.InsertPoints) == 0 {
+		return d.Payload
+	}
+	var lst []string
+	for keyword, payload := range d.InsertPoints {
+		lst = append(lst, keyword+"="+payload)
+	}
+	sort.Strings(lst)
+	return strings.Join(lst, " ")
+}
+
 func (d *Display) getDetailDiff(title, s string) string {
 	if len(s) > 0 {
 		return fmt.Sprintf("├╴%s\n%s\n", title, (d.design.Color.GREY + s + d.design.Color.WHITE))
@@ -177,6 +220,26 @@ func htmlNodeToLst(prefix string, htmlnode httpprepare.HTMLNode) []string {
 	return lst
 }
 
+// Display the confirmed timing anomaly:
+func (d Display) timing() string {
+	if t := d.Scanner.Timing; t.OK {
+		return fmt.Sprintf(" Timing: [\033[1;31m%.3fs\033[0m > %.3fs]", t.Time, t.Baseline)
+	}
+	return ""
+}
+
+// Display the out-of-band interactions:
+func (d Display) oob() string {
+	var lst []string
+	for _, i := range d.Scanner.OOB {
+		lst = append(lst, "\033[1;31m"+strings.ToUpper(i.Protocol)+"\033[0m "+i.RemoteAddr)
+	}
+	if len(lst) > 0 {
+		return " OOB: [" + strings.Join(lst, ", ") + "]"
+	}
+	return ""
+}
+
 // Display payload transformation:
 func (d Display) transformation() string {
 	if len(d.Scanner.Transformation.Format) > 0 {
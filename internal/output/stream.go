@@ -0,0 +1,44 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// The stream write each result as one compact JSON object per line (Ex: to stdout to be chained with other tools like "jq")
+type Stream struct {
+	writer io.Writer
+	body   bool
+	mutex  sync.Mutex
+}
+
+// Create a JSON stream to the writer. The response body is only included in case "body" is true.
+func NewStream(writer io.Writer, body bool) *Stream {
+	return &Stream{
+		writer: writer,
+		body:   body,
+	}
+}
+
+func (s *Stream) Open() error  { return nil }
+func (s *Stream) Close() error { return nil }
+
+// Write the result as a single line. Results that are not OK are ignored.
+func (s *Stream) Write(result ResultFinal) error {
+	if !result.OK {
+		return nil
+	}
+	if !s.body {
+		result.Response.Body = ""
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, err = s.writer.Write(append(data, '\n'))
+	return err
+}
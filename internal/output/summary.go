@@ -0,0 +1,35 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/Brum3ns/firefly/pkg/statistics"
+)
+
+// Summary of a run used in reports.
+// Note : (The statistics are only available when the report is made during the run, not when it's converted from a results file)
+type Summary struct {
+	Statistics bool
+	Requests   int
+	Responses  int
+	Scanned    int
+	Behaviors  int
+	Filtered   int
+	Errors     int
+	Time       string
+}
+
+// Make a summary from the statistics of the runner
+func NewSummary(stats statistics.Statistic) Summary {
+	t := stats.GetTime()
+	return Summary{
+		Statistics: true,
+		Requests:   stats.Request.GetCount(),
+		Responses:  stats.Response.GetCount(),
+		Scanned:    stats.Scanner.GetCount(),
+		Behaviors:  stats.Behavior.GetCount(),
+		Filtered:   stats.Response.GetFilterCount(),
+		Errors:     stats.Response.GetErrorCount(),
+		Time:       fmt.Sprintf("%02d:%02d:%02d", t[0], t[1], t[2]),
+	}
+}
@@ -0,0 +1,168 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Brum3ns/firefly/pkg/transformation"
+)
+
+// Technique that detected the behavior of a result.
+// The Id is derived from the technique and what it detected (Ex: "extract/pattern/SQL syntax", "diff/header")
+type Technique struct {
+	Id   string
+	Name string
+	Desc string
+	// Metadata of the detection rule (only set for the extract technique)
+	Category string `json:",omitempty"`
+	Severity string `json:",omitempty"`
+	CWE      string `json:",omitempty"`
+}
+
+// Get all the techniques that detected the behavior of the result.
+// Note : (In case no technique was triggered, the behavior was detected by the quick behavior checks)
+func Techniques(result ResultFinal) []Technique {
+	var (
+		lst     []Technique
+		extract = result.Scanner.Extract
+		diff    = result.Scanner.Diff
+		tfmt    = result.Scanner.Transformation
+	)
+
+	// Extract (patterns and regex found in the response that are not known by the target):
+	for _, m := range []struct {
+		typ   string
+		found map[string]int
+	}{
+		{"pattern", extract.PatternBody},
+		{"pattern", extract.PatternHeaders},
+		{"regex", extract.RegexBody},
+		{"regex", extract.RegexHeaders},
+	} {
+		for _, item := range sortedKeys(m.found) {
+			t := Technique{
+				Id:   "extract/" + m.typ + "/" + item,
+				Name: "Extract " + m.typ,
+				Desc: fmt.Sprintf("The %s %q was found in the response", m.typ, item),
+			}
+			if rule, ok := extract.Rules[item]; ok {
+				t.Category, t.Severity, t.CWE = rule.Category, rule.Severity, rule.CWE
+				t.Desc += " (" + rule.String() + ")"
+			}
+			lst = addTechnique(lst, t)
+		}
+	}
+
+	// Transformation (the payload was transformed by the target):
+	if tfmt.OK {
+		lst = addTechnique(lst, Technique{
+			Id:   "transformation/" + tfmt.Desc,
+			Name: "Transformation",
+			Desc: fmt.Sprintf("The payload %q was transformed to %q (%s)", tfmt.Payload, tfmt.Format, tfmt.Desc) + stepsDesc(tfmt.Steps),
+		})
+	}
+
+	// Difference (the response differ from the known behavior of the target):
+	for _, d := range []struct {
+		typ  string
+		hits int
+	}{
+		{"header", diff.HeaderResult.HeaderHits},
+		{"html-tag", diff.HTMLResult.Appear.TagStartHits + diff.HTMLResult.Appear.TagEndHits + diff.HTMLResult.Appear.TagSelfCloseHits},
+		{"html-attribute", diff.HTMLResult.Appear.AttributeHits},
+		{"html-attribute-value", diff.HTMLResult.Appear.AttributeValueHits},
+		{"html-words", diff.HTMLResult.Appear.WordsHits},
+		{"html-comment", diff.HTMLResult.Appear.CommentHits},
+	} {
+		if d.hits > 0 {
+			lst = addTechnique(lst, Technique{
+				Id:   "diff/" + d.typ,
+				Name: "Difference",
+				Desc: fmt.Sprintf("%d %s difference(s) compared to the known behavior of the target", d.hits, d.typ),
+			})
+		}
+	}
+
+	// Timing (the response time is significantly above the baseline of the target):
+	if t := result.Scanner.Timing; t.OK {
+		id, desc := "timing/anomaly", fmt.Sprintf("The response time %.3fs is significantly above the baseline %.3fs (reproduced)", t.Time, t.Baseline)
+		if t.Linear {
+			id, desc = "timing/linear", fmt.Sprintf("The response time %.3fs is significantly above the baseline %.3fs and linear to the delay of the payload", t.Time, t.Baseline)
+		}
+		lst = addTechnique(lst, Technique{Id: id, Name: "Timing", Desc: desc})
+	}
+
+	// Out-of-band (the target interacted with the unique host given to the request):
+	for _, i := range result.Scanner.OOB {
+		lst = addTechnique(lst, Technique{
+			Id:   "oob/" + i.Protocol,
+			Name: "Out-of-band",
+			Desc: fmt.Sprintf("%s interaction %q from %s", strings.ToUpper(i.Protocol), i.Query, i.RemoteAddr),
+		})
+	}
+
+	if len(lst) == 0 {
+		lst = append(lst, Technique{
+			Id:   "behavior/unknown",
+			Name: "Unknown behavior",
+			Desc: "The response differ from the known behavior of the target",
+		})
+	}
+	return lst
+}
+
+func addTechnique(lst []Technique, t Technique) []Technique {
+	for _, i := range lst {
+		if i.Id == t.Id {
+			return lst
+		}
+	}
+	return append(lst, t)
+}
+
+// Describe the transformation steps with what each character became (Ex: `: url-decode ["%3C" => "<"]`)
+func stepsDesc(steps []transformation.Step) string {
+	var lst []string
+	for _, s := range steps {
+		lst = append(lst, s.String())
+	}
+	if len(lst) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(lst, " > ")
+}
+
+func sortedKeys(m map[string]int) []string {
+	var lst []string
+	for k := range m {
+		lst = append(lst, k)
+	}
+	sort.Strings(lst)
+	return lst
+}
+
+// Score of the result based on the amount of hits from the techniques that detected the behavior.
+// Note : (A transformation, timing anomaly or out-of-band interaction count as one hit and a result detected by the quick behavior checks only has the score zero)
+func Score(result ResultFinal) int {
+	var (
+		score   int
+		extract = result.Scanner.Extract
+		diff    = result.Scanner.Diff
+		appear  = diff.HTMLResult.Appear
+	)
+	for _, m := range []map[string]int{extract.PatternBody, extract.PatternHeaders, extract.RegexBody, extract.RegexHeaders} {
+		score += sumHits(m)
+	}
+	if result.Scanner.Transformation.OK {
+		score++
+	}
+	if result.Scanner.Timing.OK {
+		score++
+	}
+	score += len(result.Scanner.OOB)
+	score += diff.HeaderResult.HeaderHits
+	score += appear.TagStartHits + appear.TagEndHits + appear.TagSelfCloseHits
+	score += appear.AttributeHits + appear.AttributeValueHits + appear.WordsHits + appear.CommentHits
+	return score
+}
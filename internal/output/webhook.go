@@ -0,0 +1,333 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Brum3ns/firefly/pkg/design"
+)
+
+// Webhook templates for chat services (Ex: "slack", "discord")
+// Note : (The default (no template) payload is a JSON array of the results)
+var WEBHOOK_TEMPLATES = map[string]string{
+	"slack":   `{"text":{{json (summaries .Results)}}}`,
+	"discord": `{"content":{{json (truncate 2000 (summaries .Results))}}}`,
+}
+
+var (
+	WEBHOOK_FLUSH_INTERVAL = 10 * time.Second
+	WEBHOOK_RETRY_DELAY    = time.Second
+)
+
+// Configuration of the webhook sink
+type WebhookConfig struct {
+	URL string
+	// Template (name, file or inline) used to make the payload of a batch of results
+	Template string
+	// Amount of results to send in each request
+	Batch int
+	// Amount of retries of a failed request (the delay is doubled for each retry)
+	Retry      int
+	RetryDelay time.Duration
+	// Minimum score of the results to send
+	MinScore int
+	// The results must be detected by one of the techniques (prefix of the technique id, Ex: "extract", "diff/header")
+	Techniques []string
+}
+
+// The webhook sink send the results in batches to a webhook URL (Ex: Slack, Discord or a custom HTTP server)
+type Webhook struct {
+	config   WebhookConfig
+	client   *http.Client
+	template *template.Template
+	pending  []ResultFinal
+	batches  chan []ResultFinal
+	done     chan bool
+	closed   bool
+	mutex    sync.Mutex
+	wg       sync.WaitGroup
+	// Batches taken from the pending results that are not yet given to the sender
+	inflight sync.WaitGroup
+	err      error
+	errMutex sync.Mutex
+}
+
+// Data given to the webhook template
+type webhookData struct {
+	Results []ResultFinal
+	Count   int
+}
+
+func NewWebhook(config WebhookConfig) (*Webhook, error) {
+	tmpl, err := WebhookTemplate(config.Template)
+	if err != nil {
+		return nil, err
+	}
+	if config.Batch <= 0 {
+		config.Batch = 1
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = WEBHOOK_RETRY_DELAY
+	}
+	w := &Webhook{
+		config:   config,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		template: tmpl,
+		batches:  make(chan []ResultFinal, 16),
+		done:     make(chan bool),
+	}
+
+	w.wg.Add(1)
+	go w.sender()
+	go w.ticker()
+	return w, nil
+}
+
+// Parse the webhook template. The template can be the name of a built-in template, a file or the template itself.
+// Return a nil template in case no template is given (the results are sent as JSON)
+func WebhookTemplate(s string) (*template.Template, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	if t, ok := WEBHOOK_TEMPLATES[strings.ToLower(s)]; ok {
+		s = t
+	} else if data, err := os.ReadFile(s); err == nil {
+		s = string(data)
+	}
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json":       webhookJSON,
+		"summary":    webhookSummary,
+		"summaries":  webhookSummaries,
+		"techniques": Techniques,
+		"curl":       Curl,
+		"truncate":   truncate,
+	}).Parse(s)
+}
+
+// The webhook is ready to send results once created (see: NewWebhook)
+func (w *Webhook) Open() error {
+	return nil
+}
+
+// Add the result to the current batch. Results that do not pass the filters (score, techniques) are ignored.
+// The batch is sent when it's full or when the flush interval is reached.
+func (w *Webhook) Write(result ResultFinal) error {
+	if !result.OK || !w.match(result) {
+		return nil
+	}
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return errors.New("the webhook is closed")
+	}
+	w.pending = append(w.pending, result)
+	var batch []ResultFinal
+	if len(w.pending) >= w.config.Batch {
+		batch = w.take()
+	}
+	w.mutex.Unlock()
+
+	w.enqueue(batch, false)
+	return nil
+}
+
+// Send the remaining results and wait until all batches are sent. Return the last error (if any).
+func (w *Webhook) Close() error {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return w.lastErr()
+	}
+	w.closed = true
+	batch := w.take()
+	w.mutex.Unlock()
+
+	// The last batch is always given to the sender, then no more batches can be taken once closed:
+	w.enqueue(batch, true)
+	w.inflight.Wait()
+	close(w.batches)
+	close(w.done)
+
+	w.wg.Wait()
+	return w.lastErr()
+}
+
+// Check if the result pass the filters of the webhook
+func (w *Webhook) match(result ResultFinal) bool {
+	if Score(result) < w.config.MinScore {
+		return false
+	}
+	if len(w.config.Techniques) == 0 {
+		return true
+	}
+	for _, t := range Techniques(result) {
+		for _, prefix := range w.config.Techniques {
+			if strings.HasPrefix(t.Id, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Take the pending results as a batch that must then be given to the sender (see: enqueue)
+// Note : (The mutex must be locked by the caller)
+func (w *Webhook) take() []ResultFinal {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	batch := w.pending
+	w.pending = nil
+	w.inflight.Add(1)
+	return batch
+}
+
+// Give the batch to the sender. Unless blocking, the batch is dropped when the queue is full (Ex: the webhook is down and the requests are retried).
+// Note : (The mutex must NOT be locked by the caller, the sender may be busy for a long time)
+func (w *Webhook) enqueue(batch []ResultFinal, block bool) {
+	if batch == nil {
+		return
+	}
+	defer w.inflight.Done()
+	if block {
+		w.batches <- batch
+		return
+	}
+	select {
+	case w.batches <- batch:
+	default:
+		log.Println(design.STATUS.ERROR, "Webhook: the queue is full,", len(batch), "result(s) dropped")
+	}
+}
+
+// Flush the pending results at each interval to not keep results waiting for a full batch
+func (w *Webhook) ticker() {
+	t := time.NewTicker(WEBHOOK_FLUSH_INTERVAL)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-t.C:
+			var batch []ResultFinal
+			w.mutex.Lock()
+			if !w.closed {
+				batch = w.take()
+			}
+			w.mutex.Unlock()
+			w.enqueue(batch, false)
+		}
+	}
+}
+
+func (w *Webhook) sender() {
+	defer w.wg.Done()
+	for batch := range w.batches {
+		if err := w.send(batch); err != nil {
+			log.Println(design.STATUS.ERROR, "Webhook:", err)
+			w.errMutex.Lock()
+			w.err = err
+			w.errMutex.Unlock()
+		}
+	}
+}
+
+// Get the last error of the sender (if any)
+func (w *Webhook) lastErr() error {
+	w.errMutex.Lock()
+	defer w.errMutex.Unlock()
+	return w.err
+}
+
+// Send a batch of results. Failed requests (connection errors, status 429 and 5xx) are retried.
+func (w *Webhook) send(batch []ResultFinal) error {
+	body, err := w.payload(batch)
+	if err != nil {
+		return err
+	}
+	delay := w.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil || errors.Is(err, errNoRetry) || attempt >= w.config.Retry {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+var errNoRetry = errors.New("the webhook rejected the request")
+
+func (w *Webhook) post(body []byte) error {
+	resp, err := w.client.Post(w.config.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("the webhook responded with status %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("%w (status %d)", errNoRetry, resp.StatusCode)
+	}
+}
+
+// Make the payload of the batch from the template (if any), otherwise the batch is sent as a JSON array
+func (w *Webhook) payload(batch []ResultFinal) ([]byte, error) {
+	if w.template == nil {
+		return json.Marshal(batch)
+	}
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, webhookData{Results: batch, Count: len(batch)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func webhookJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// Make a single line summary of the result (Ex: "#12 [200] GET http://example.com/?q=' payload:' (diff/html-words)")
+func webhookSummary(result ResultFinal) string {
+	var ids []string
+	for _, t := range Techniques(result) {
+		ids = append(ids, t.Id)
+	}
+	return fmt.Sprintf("#%d [%d] %s %s payload:%s (%s)",
+		result.RequestId,
+		result.Response.StatusCode,
+		result.Request.Method,
+		result.Request.URL,
+		result.Payload,
+		strings.Join(ids, ", "),
+	)
+}
+
+func webhookSummaries(results []ResultFinal) string {
+	var lst []string
+	for _, result := range results {
+		lst = append(lst, webhookSummary(result))
+	}
+	return "Firefly - " + fmt.Sprint(len(results)) + " finding(s)\n" + strings.Join(lst, "\n")
+}
+
+func truncate(n int, s string) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return s
+}
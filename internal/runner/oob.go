@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"log"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Brum3ns/firefly/internal/config"
+	"github.com/Brum3ns/firefly/internal/knowledge"
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/internal/verbose"
+	"github.com/Brum3ns/firefly/pkg/oob"
+	"github.com/Brum3ns/firefly/pkg/request"
+)
+
+// The OOB listener match the interactions received by the out-of-band server back to the requests that were given the unique hosts.
+// Each interaction is given as a result (finding) of its request.
+type oobListener struct {
+	server *oob.Server
+	wait   time.Duration
+	// The requests that were given a unique host (request id|result)
+	requests map[int]output.ResultFinal
+	mutex    sync.Mutex
+	done     chan bool
+}
+
+// The unique hosts made for one request
+type oobHosts struct {
+	server *oob.Server
+	ids    []string
+}
+
+// Make a new unique host for the request
+func (h *oobHosts) Host() string {
+	host, id := h.server.Host()
+	h.ids = append(h.ids, id)
+	return host
+}
+
+// Start the OOB server used for the keyword "#OOB#".
+// Return nil in case the OOB server is not used or the runner is in verify mode.
+// Note : (will panic in case an error is triggered)
+func mustNewOOBListener(conf *config.Configure, knowledgeStorage map[string]knowledge.Knowledge) *oobListener {
+	if !conf.Option.OOB || knowledgeStorage == nil {
+		return nil
+	}
+	server, err := oob.NewServer(oob.Config{
+		Domain:      conf.Option.OOBDomain,
+		HTTPAddress: conf.Option.OOBHTTP,
+		DNSAddress:  conf.Option.OOBDNS,
+		IP:          conf.Option.OOBIP,
+	})
+	if err != nil {
+		log.Panicln(err)
+	}
+	server.Start()
+	verbose.Show("OOB server (HTTP: " + server.HTTPAddress() + ", DNS: " + server.DNSAddress() + ")")
+
+	return &oobListener{
+		server:   server,
+		wait:     time.Duration(conf.Option.OOBWait) * time.Second,
+		requests: make(map[int]output.ResultFinal),
+		done:     make(chan bool),
+	}
+}
+
+// Get a new set of unique hosts for one request (see: Track)
+func (l *oobListener) Hosts() *oobHosts {
+	return &oobHosts{server: l.server}
+}
+
+// Assign the unique hosts made for the request to it (if any).
+// Note : (Must be used before the request is sent and once the request is given its id)
+func (l *oobListener) Track(settings request.RequestSettings, hosts *oobHosts) {
+	if len(hosts.ids) == 0 {
+		return
+	}
+	requestId := settings.RequestId
+	l.server.Assign(requestId, hosts.ids)
+
+	var headers [][2]string
+	for name, values := range settings.Headers {
+		for _, v := range values {
+			headers = append(headers, [2]string{name, v})
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i][0] < headers[j][0] })
+
+	var host, scheme string
+	if u, err := url.Parse(settings.URL); err == nil {
+		host, scheme = u.Host, u.Scheme
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.requests[requestId] = output.ResultFinal{
+		RequestId:    requestId,
+		TargetHashId: settings.TargetHashId,
+		Tag:          settings.Tag,
+		Payload:      settings.Payload,
+		InsertPoints: settings.InsertPoints,
+		Request: output.Request{
+			URL:         settings.URL,
+			URLOriginal: settings.URLOriginal,
+			Host:        host,
+			Scheme:      scheme,
+			Method:      settings.Method,
+			PostBody:    settings.PostBody,
+			Headers:     headers,
+		},
+	}
+}
+
+// Give each interaction as a result of its request to the channel until the listener is closed
+func (l *oobListener) Listen(channel chan<- output.ResultFinal) {
+	go func() {
+		defer close(l.done)
+		for interaction := range l.server.Interactions {
+			l.mutex.Lock()
+			result, ok := l.requests[interaction.RequestId]
+			l.mutex.Unlock()
+			if !ok {
+				continue
+			}
+			result.Date = interaction.Date
+			result.OK = true
+			result.UnkownBehavior = true
+			result.Scanner.OOB = []oob.Interaction{interaction}
+			channel <- result
+		}
+	}()
+}
+
+// Wait for delayed interactions, then close the OOB server and wait until all the interactions are given
+func (l *oobListener) Close() {
+	time.Sleep(l.wait)
+	if err := l.server.Close(); err != nil {
+		verbose.Show(err)
+	}
+	<-l.done
+}
@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/Brum3ns/firefly/internal/config"
@@ -15,13 +14,13 @@ import (
 	"github.com/Brum3ns/firefly/internal/knowledge"
 	"github.com/Brum3ns/firefly/internal/output"
 	"github.com/Brum3ns/firefly/internal/scan"
-	"github.com/Brum3ns/firefly/internal/ui"
 	"github.com/Brum3ns/firefly/internal/verbose"
 	"github.com/Brum3ns/firefly/pkg/design"
+	"github.com/Brum3ns/firefly/pkg/expression"
 	"github.com/Brum3ns/firefly/pkg/files"
 	"github.com/Brum3ns/firefly/pkg/httpfilter"
-	"github.com/Brum3ns/firefly/pkg/httpprepare"
 	"github.com/Brum3ns/firefly/pkg/insertpoint"
+	"github.com/Brum3ns/firefly/pkg/parameter"
 	"github.com/Brum3ns/firefly/pkg/payloads"
 	"github.com/Brum3ns/firefly/pkg/request"
 	"github.com/Brum3ns/firefly/pkg/statistics"
@@ -31,16 +30,18 @@ import (
 // The runner should contain the structures needed for all the processes.
 // It must NOT contain structures that need to be modified and/or dynamicly changed once the process is running.
 type Runner struct {
-	Count          int
-	OutputOK       bool
-	VerifyMode     bool
-	TerminalUIMode bool
-	Conf           *config.Configure
-	Design         *design.Design
-	RequestTasks   *request.TaskStorage
-	stats          statistics.Statistic
-	channel        Channel
-	handler        Handler
+	Count        int
+	VerifyMode   bool
+	Conf         *config.Configure
+	Design       *design.Design
+	RequestTasks *request.TaskStorage
+	stats        statistics.Statistic
+	channel      Channel
+	handler      Handler
+	// Auto calibration filters of the targets (target hash|filter)
+	calibration map[string]httpfilter.Calibration
+	timing      *TimingAnalyzer
+	oob         *oobListener
 }
 
 type Handler struct {
@@ -60,13 +61,14 @@ type Channel struct {
 func NewRunner(conf *config.Configure, knowledgeStorage map[string]knowledge.Knowledge) *Runner {
 	var verifyMode = (knowledgeStorage == nil)
 	return &Runner{
-		Count:          0,
-		Conf:           conf,
-		VerifyMode:     verifyMode,
-		TerminalUIMode: (!verifyMode && conf.Option.TerminalUI),
-		OutputOK:       (len(conf.Option.Output) > 0 && knowledgeStorage != nil),
-		Design:         design.NewDesign(),
-		stats:          statistics.NewStatistic(verifyMode),
+		Count:       0,
+		Conf:        conf,
+		VerifyMode:  verifyMode,
+		Design:      design.NewDesign(),
+		stats:       statistics.NewStatistic(verifyMode),
+		calibration: newCalibration(conf, knowledgeStorage),
+		timing:      newTimingAnalyzer(conf, knowledgeStorage),
+		oob:         mustNewOOBListener(conf, knowledgeStorage),
 		channel: Channel{
 			ListenerScanner: make(chan scan.Result),
 			ListenerHTTP:    make(chan request.Result),
@@ -107,76 +109,53 @@ func NewRunner(conf *config.Configure, knowledgeStorage map[string]knowledge.Kno
 // The runner is the core process for all other child processes. It's preforming the requests and listen for HTTP results to be scanned analyzed.
 func (r *Runner) Run() (map[string]knowledge.Knowledge, statistics.Statistic, error) {
 	var (
-		outputFileWriter = r.MustValidateOutput()
-		learnt           = make(map[string][]knowledge.Learnt)
-		display          = output.NewDisplay(r.Conf.Option.Detail, r.Design)
-		terminalUI       = ui.NewProgram()
-		wg               waitgroup.WaitGroup
+		learnt = newLearntSink()
+		sinks  = r.MustSetupSinks(learnt)
+		wg     waitgroup.WaitGroup
 	)
 
-	// Start terminal UI
-	if r.TerminalUIMode {
-		wg.Add(1)
-		go func() {
-			if _, err := terminalUI.Run(); err != nil {
-				log.Fatalf("terminal UI - %s", err)
-			}
-			wg.Done()
-		}()
+	// Open all the sinks that the results are given to (Ex: output file, webhook, terminal UI)
+	if err := sinks.Open(); err != nil {
+		log.Panicln(err)
 	}
 
 	// Start the request and scanner handlers
 	go r.handler.HTTP.Run(r.channel.ListenerHTTP)
 	go r.handler.Scanner.Run(r.channel.ListenerScanner)
+	if r.oob != nil {
+		r.oob.Listen(r.channel.Result)
+	}
 
 	//Runner listener
 	go func() {
-		var (
-			progressbar = ui.NewProgressBar(100, &r.stats)
-			//progressBar = statistics.NewProgressBar(100, &r.stats)
-			mutex sync.Mutex
-		)
 		for {
 			select {
 			case <-r.channel.Statistic:
-				if !r.Conf.Option.NoDisplay && r.TerminalUIMode {
-					terminalUI.Send(r.stats)
-				}
+				sinks.Statistic(r.stats)
 
 			case result := <-r.channel.Result:
+				// Results with a suspicious response time are analyzed in the background and handled once they are given back:
+				if r.timing != nil && r.timing.Suspicious(result) && r.timing.Analyze(result, r.channel.Result) {
+					continue
+				}
 				r.stats.Count()
 
-				if r.VerifyMode {
-					mutex.Lock()
-					learnt[result.TargetHashId] = append(learnt[result.TargetHashId], knowledge.Learnt{
-						Payload:  result.Payload,
-						Extract:  result.Scanner.Extract,
-						HTMLNode: httpprepare.GetHTMLNode(result.Response.Body),
-						Response: result.Response,
-					})
-					mutex.Unlock()
-				} else if result.UnkownBehavior {
-					r.stats.Behavior.Count()
-
-					// Send the result to the output file specified by the user:
-					if r.OutputOK {
-						err := output.WriteJSON(r.stats.Output.GetCount(), outputFileWriter, result)
-						if err != nil {
-							log.Println(design.STATUS.ERROR, "Request ID:", result.RequestId, err)
-						}
-						r.stats.Output.Count()
-					}
+				// Filter/match expressions that use the result fields (Ex: extract, transformation) are used once the response is scanned:
+				if !r.VerifyMode && result.UnkownBehavior && r.expressionFilter(true, func() expression.Env { return output.Env(result) }) {
+					r.stats.Response.CountFilter()
+					continue
+				}
 
-					// Display the final result to the screen (CLI)
-					if !r.Conf.Option.NoDisplay {
-						if r.TerminalUIMode {
-							terminalUI.Send(r.stats)
-							terminalUI.Send(result)
-						} else {
-							display.ToScreen(result)
-							progressbar.Print()
-						}
-					}
+				if !r.VerifyMode && result.UnkownBehavior {
+					r.stats.Behavior.Count()
+				}
+				// Give the result to the sinks that accept it:
+				count, err := sinks.Write(result)
+				if err != nil {
+					log.Println(design.STATUS.ERROR, "Request ID:", result.RequestId, err)
+				}
+				if !r.VerifyMode && count > 0 {
+					r.stats.Output.Count()
 				}
 			}
 		}
@@ -194,20 +173,60 @@ func (r *Runner) Run() (map[string]knowledge.Knowledge, statistics.Statistic, er
 	// Wait for the handlers to finish
 	r.handler.HTTP.Wait()
 	r.handler.Scanner.Wait()
+	if r.timing != nil {
+		r.timing.Close()
+	}
+	if r.oob != nil {
+		r.oob.Close()
+	}
 
-	// Close the output file (if any output  have been handled)
-	if r.OutputOK {
-		if err := outputFileWriter.Close(); err != nil {
-			log.Fatal(err)
-		}
+	// Close all the sinks (the reports are given the summary of the run before they are closed)
+	sinks.SetSummary(output.NewSummary(r.stats))
+	if err := sinks.Close(); err != nil {
+		log.Fatal(err)
 	}
 
-	if r.TerminalUIMode {
-		terminalUI.Quit()
-		wg.Wait()
+	return learnt.Knowledge(), r.stats, nil
+}
+
+// Register the sinks that the results are given to.
+// In verify mode all results are stored as knowledge, otherwise the results with an unknown behavior are given to the output file, webhook and screen (if set).
+// Note : (will panic in case an error is triggered)
+func (r *Runner) MustSetupSinks(learnt *learntSink) *output.Sinks {
+	sinks := output.NewSinks()
+	if r.VerifyMode {
+		sinks.Register("knowledge", learnt, nil)
+		return sinks
 	}
 
-	return knowledge.GetKnowledge(learnt), r.stats, nil
+	behavior := func(result output.ResultFinal) bool {
+		return result.UnkownBehavior
+	}
+	if len(r.Conf.Option.Output) > 0 {
+		sinks.Register("output", r.MustValidateOutput(), behavior)
+	}
+	if len(r.Conf.Option.Webhook) > 0 {
+		sinks.Register("webhook", r.MustValidateWebhook(), behavior)
+	}
+	if r.Conf.Option.JSON {
+		sinks.Register("stdout", output.NewStream(os.Stdout, r.Conf.Option.OutputBody), behavior)
+	}
+	if !r.Conf.Option.NoDisplay {
+		switch {
+		case r.Conf.Option.JSON:
+			// Note : (The results are already given to stdout as JSON, only the progress is shown (stderr))
+			sinks.Register("progress", newScreenSink(nil, nil, &r.stats), behavior)
+		case r.Conf.Option.TerminalUI:
+			sinks.Register("terminal UI", newTerminalUISink(&r.stats), behavior)
+		default:
+			var clusters *output.Clusters
+			if r.Conf.Option.Cluster {
+				clusters = output.NewClusters()
+			}
+			sinks.Register("screen", newScreenSink(output.NewDisplay(r.Conf.Option.Detail, r.Design), clusters, &r.stats), behavior)
+		}
+	}
+	return sinks
 }
 
 // Listen for results from the HTTP handler and preform a scan for each intercepted HTTP result:
@@ -251,7 +270,7 @@ func (r *Runner) listenerHTTP() {
 		}
 
 		// HTTP Filter filter/match (if set)
-		if r.Conf.Httpfilter.Run(filterResp) || (r.Conf.HttpMatch.IsSet() && !r.Conf.HttpMatch.Run(filterResp)) {
+		if r.Conf.Httpfilter.Run(filterResp) || (r.Conf.HttpMatch.IsSet() && !r.Conf.HttpMatch.Run(filterResp)) || r.expressionFilter(false, filterResp.Env) || r.calibration[resultHTTP.TargetHashId].Run(filterResp) {
 			r.stats.Response.CountFilter()
 			r.channel.Statistic <- true
 			continue
@@ -262,26 +281,66 @@ func (r *Runner) listenerHTTP() {
 	}
 }
 
+// Make the auto calibration filters of the targets from their baseline (the verification and calibration responses).
+// Return nil in case auto calibration is not used or the runner is in verify mode.
+func newCalibration(conf *config.Configure, knowledgeStorage map[string]knowledge.Knowledge) map[string]httpfilter.Calibration {
+	if !conf.Option.AutoCalibrate || knowledgeStorage == nil {
+		return nil
+	}
+	m := make(map[string]httpfilter.Calibration)
+	for hash, k := range knowledgeStorage {
+		var responses []httpfilter.Response
+		for _, resp := range append(append([]output.Response{}, k.Responses...), k.Calibration...) {
+			responses = append(responses, httpfilter.Response{
+				StatusCode:   resp.StatusCode,
+				ResponseSize: resp.ContentLength,
+				WordCount:    resp.WordCount,
+				LineCount:    resp.LineCount,
+			})
+		}
+		if calibration, ok := httpfilter.NewCalibration(responses); ok {
+			m[hash] = calibration
+			verbose.Show("Auto calibration (" + hash + "): " + calibration.String())
+		} else {
+			verbose.Show("Auto calibration (" + hash + "): the target can't be calibrated")
+		}
+	}
+	return m
+}
+
+// Check if the filter/match expressions (if set) filter the response. The environment is only made in case it's needed.
+// Expressions that use the result fields are only used once the response is scanned ("scanned" is true) and the others before the scan.
+func (r *Runner) expressionFilter(scanned bool, env func() expression.Env) bool {
+	var (
+		filter = r.Conf.FilterExpression
+		match  = r.Conf.MatchExpression
+	)
+	if filter != nil && filter.Uses(output.EXPRESSION_FIELDS...) != scanned {
+		filter = nil
+	}
+	if match != nil && match.Uses(output.EXPRESSION_FIELDS...) != scanned {
+		match = nil
+	}
+	if filter == nil && match == nil {
+		return false
+	}
+	e := env()
+	return (filter != nil && filter.Eval(e)) || (match != nil && !match.Eval(e))
+}
+
 // Validate and verify the output to store the result to (if set):
 // Note : (will panic in case an error is triggered)
-func (r *Runner) MustValidateOutput() *os.File {
+func (r *Runner) MustValidateOutput() *output.Writer {
 	var (
-		fileWriter = &os.File{}
+		fileWriter *output.Writer
 		err        error
 	)
 	//Create output file and create a file writer (*if output file set*):
-	if r.OutputOK {
+	if len(r.Conf.Option.Output) > 0 {
 		if !files.FileExist(r.Conf.Option.Output) || r.Conf.Option.Overwrite {
-			fileWriter, err = os.OpenFile(r.Conf.Option.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-
+			fileWriter, err = output.NewWriter(r.Conf.Option.Output, r.Conf.Option.OutputFormat, r.Conf.Option.OutputBody)
 			if err != nil {
 				log.Panicln(err)
-
-			} else if err = fileWriter.Truncate(0); err != nil {
-				log.Panicln(err)
-
-			} else if _, err = fileWriter.Seek(0, 0); err != nil {
-				log.Panicln(err)
 			}
 		} else {
 			err = fmt.Errorf("%s The specified output file already exists (\033[33m%s\033[0m), use the overwrite option to overwrite it", design.STATUS.FAIL, r.Conf.Option.Output)
@@ -292,78 +351,250 @@ func (r *Runner) MustValidateOutput() *os.File {
 	return fileWriter
 }
 
+// Setup the webhook to send the results to (if set):
+// Note : (will panic in case an error is triggered)
+func (r *Runner) MustValidateWebhook() *output.Webhook {
+	if len(r.Conf.Option.Webhook) == 0 {
+		return nil
+	}
+	webhook, err := output.NewWebhook(output.WebhookConfig{
+		URL:        r.Conf.Option.Webhook,
+		Template:   r.Conf.Option.WebhookTemplate,
+		Batch:      r.Conf.Option.WebhookBatch,
+		Retry:      r.Conf.Option.WebhookRetry,
+		MinScore:   r.Conf.Option.WebhookScore,
+		Techniques: r.Conf.Option.WebhookTechniques,
+	})
+	if err != nil {
+		log.Panicln(err)
+	}
+	verbose.Show("Send result to webhook: " + r.Conf.Option.Webhook)
+	return webhook
+}
+
 func (r *Runner) jobToHandler(requestHandler *request.Handler) int {
 	var (
 		payloadWordlist = r.Conf.Wordlist.GetAll()
-		headersArray    = r.Conf.Option.Headers
-		postbody        = r.Conf.Option.PostData
 		jobAmount       = 0
 	)
+	randomUserAgents, err := getRandomUserAgent(global.FILE_RANDOMAGENT)
+	if err != nil {
+		log.Fatalln("Random User-Agent:", err)
+	}
+
 	for hash, host := range r.Conf.Option.Hosts {
-		param := r.Conf.Option.Params[hash]
-		rawURL := host.URL
+		var (
+			param    = r.Conf.Option.Params[hash]
+			rawURL   = host.URL
+			postbody = r.Conf.Option.PostData
+			// Note : (The headers are copied since the cookie header can be modified for the host)
+			headersArray = append([][2]string{}, r.Conf.Option.Headers...)
+		)
+		// Use the host properties if they were set (Ex: given by a scope file):
+		if len(host.Headers) > 0 {
+			headersArray = append([][2]string{}, host.Headers...)
+		}
+		if len(host.PostData) > 0 {
+			postbody = host.PostData
+		}
+		templates := makeTemplates(param, rawURL, postbody, headersArray)
 
 		for _, tag := range payloads.TAGS {
 			// Check if we should adapt to "behavior verification mode":
-			if (r.VerifyMode && tag != payloads.TAG_VERIFY) || (!r.VerifyMode && tag == payloads.TAG_VERIFY) {
+			if r.VerifyMode != payloads.IsVerifyTag(tag) {
 				continue
 			}
 
 			wordlist := payloadWordlist[tag]
-			for _, payload := range wordlist {
-				// Prepare the request by inserting the current payload into the request:
-				// !Note : (Some variables given will be modified)
-				insert := insertpoint.NewInsert(r.Conf.Option.InsertKeyword, payload)
+			if hostWordlist, ok := r.Conf.HostWordlist[hash]; ok && tag == payloads.TAG_FUZZ {
+				wordlist = hostWordlist
+			}
 
-				URLStruct, _ := url.Parse(rawURL)
+			for _, insert := range r.makeInserts(tag, wordlist) {
+				for _, template := range templates {
+					// Each request is given its own out-of-band host(s) (if used):
+					var (
+						insert = insert
+						hosts  *oobHosts
+					)
+					if r.oob != nil {
+						hosts = r.oob.Hosts()
+						insert = insert.OOBInsert(hosts.Host)
+					}
 
-				if param.AutoQueryURL {
-					URLStruct.RawQuery = param.URL.RawQueryInsertPoint
-					rawURL = URLStruct.String()
+					// Prepare the request by inserting the current payload into the request:
+					// !Note : (Some variables given will be modified)
+					body, headers := setPostBody(insert, param, template.postbody, template.headers)
+
+					requestSettings := request.RequestSettings{
+						UserAgents:   randomUserAgents,
+						TargetHashId: hash,
+						Tag:          tag,
+						Payload:      insert.Payload,
+						InsertPoints: insert.Points,
+						URLOriginal:  template.rawURL,
+						Parameter:    param,
+						URL:          insert.SetURL(template.rawURL),
+						Method:       insert.SetMethod(host.Method),
+						RequestBase: request.RequestBase{
+							Headers:              insert.SetHeaders(headers),
+							PostBody:             body,
+							RandomUserAgent:      r.Conf.Option.RandomAgent,
+							HeadersOriginalArray: template.headers,
+							PostBodyOriginal:     template.postbody,
+						},
+					}
+					if hosts != nil {
+						requestSettings.RequestId = requestHandler.NewRequestId()
+						r.oob.Track(requestSettings, hosts)
+					}
+					jobAmount++
+					requestHandler.AddJob(requestSettings)
 				}
+			}
+		}
+	}
+	return jobAmount
+}
 
-				if param.AutoQueryBody {
-					postbody = param.Body.RawQueryInsertPoint
-				}
+// Request properties (URL, post body and headers) that the payloads are inserted into
+type requestTemplate struct {
+	rawURL   string
+	postbody string
+	headers  [][2]string
+}
 
-				if param.AutoQueryCookie {
-					headersArray = request.SetNewHeaderValue(headersArray, "cookie", param.Cookie.RawQueryInsertPoint)
-				}
+// Make the request templates of a host adapted to the auto detected parameter positions.
+// Positions that are fuzzed all at once (Ex: url, body, cookie) share one template while each variant of the positions that are fuzzed one at a time (header, path) gets its own template based on the original request.
+func makeTemplates(param parameter.Parameter, rawURL, postbody string, headersArray [][2]string) []requestTemplate {
+	var (
+		templates []requestTemplate
+		base      = requestTemplate{rawURL: rawURL, postbody: postbody, headers: headersArray}
+	)
 
-				randomUserAgents, err := getRandomUserAgent(global.FILE_RANDOMAGENT)
-				if err != nil {
-					log.Fatalf("Random User-Agent:", err)
-				}
+	if param.AutoQueryURL {
+		URLStruct, _ := url.Parse(base.rawURL)
+		URLStruct.RawQuery = param.URL.RawQueryInsertPoint
+		base.rawURL = URLStruct.String()
+	}
 
-				requestSettings := request.RequestSettings{
-					UserAgents:   randomUserAgents,
-					TargetHashId: hash,
-					Tag:          tag,
-					Payload:      payload,
-					URLOriginal:  rawURL,
-					Parameter:    r.Conf.Option.Params[hash],
-					URL:          insert.SetURL(rawURL),
-					Method:       insert.SetMethod(host.Method),
-					RequestBase: request.RequestBase{
-						Headers:              insert.SetHeaders(headersArray),
-						PostBody:             insert.SetPostBody(postbody),
-						RandomUserAgent:      r.Conf.Option.RandomAgent,
-						HeadersOriginalArray: r.Conf.Option.Headers,
-					},
-				}
-				jobAmount++
-				requestHandler.AddJob(requestSettings)
+	if param.AutoQueryBody {
+		base.postbody = param.Body.RawQueryInsertPoint
+	}
+
+	if param.AutoQueryCookie {
+		base.headers = request.SetNewHeaderValue(append([][2]string{}, base.headers...), "cookie", param.Cookie.RawQueryInsertPoint)
+	}
+
+	if param.AutoQueryJSON && len(param.JSON.RawQueryInsertPoint) > 0 {
+		base.postbody = param.JSON.RawQueryInsertPoint
+	}
+
+	if param.AutoQueryXML && len(param.XML.RawQueryInsertPoint) > 0 {
+		base.postbody = param.XML.RawQueryInsertPoint
+	}
+
+	if param.AutoQueryMultipart && len(param.Multipart.RawQueryInsertPoint) > 0 {
+		base.postbody = param.Multipart.RawQueryInsertPoint
+	}
+
+	// The base template is used unless only the header and/or path positions are auto detected:
+	if param.AutoQueryURL || param.AutoQueryBody || param.AutoQueryCookie || param.AutoQueryJSON || param.AutoQueryXML || param.AutoQueryMultipart || !(param.AutoQueryHeader || param.AutoQueryPath) {
+		templates = append(templates, base)
+	}
+
+	if param.AutoQueryPath {
+		for _, rawPath := range param.Path.RawQueryInsertPoints {
+			URLStruct, _ := url.Parse(rawURL)
+			URLStruct.Path, _ = url.PathUnescape(rawPath)
+			URLStruct.RawPath = rawPath
+			templates = append(templates, requestTemplate{rawURL: URLStruct.String(), postbody: postbody, headers: headersArray})
+		}
+	}
+
+	if param.AutoQueryHeader {
+		for _, headers := range param.HeaderInsertPoints(headersArray) {
+			templates = append(templates, requestTemplate{rawURL: rawURL, postbody: postbody, headers: headers})
+		}
+	}
+	return templates
+}
+
+// Insert the payload into the post body adapted to the auto detected parameter position (if any). Return the post body and the headers to use with it.
+// Note : (The content type header is updated in case a new multipart boundary is used)
+func setPostBody(insert insertpoint.Insert, param parameter.Parameter, postbody string, headers [][2]string) (string, [][2]string) {
+	switch {
+	case param.AutoQueryJSON:
+		return insert.SetJSONPostBody(postbody), headers
+
+	case param.AutoQueryXML:
+		return insert.SetXMLPostBody(postbody), headers
+
+	case param.AutoQueryMultipart:
+		body, boundary := insert.SetMultipartPostBody(postbody, param.Multipart.Boundary)
+		if boundary != param.Multipart.Boundary {
+			_, contentType := request.GetHeader(headers, "content-type")
+			headers = request.SetNewHeaderValue(append([][2]string{}, headers...), "content-type", strings.Replace(contentType, param.Multipart.Boundary, boundary, 1))
+		}
+		return body, headers
+	}
+	return insert.SetPostBody(postbody), headers
+}
+
+// Make the request of a result with another payload inserted into the same insert point(s) as the payload of the result (Ex: a scaled time based payload).
+// The payload is inserted into the request before the insertion (see: output.Request) the same way as the original payload was.
+func RebuildRequest(result output.ResultFinal, payload, keyword string, param parameter.Parameter) request.RequestSettings {
+	insert := insertpoint.NewInsert(keyword, payload)
+	if len(result.InsertPoints) > 0 {
+		insert.Points = make(map[string]string, len(result.InsertPoints))
+		for k, p := range result.InsertPoints {
+			if p == result.Payload {
+				p = payload
 			}
+			insert.Points[k] = p
 		}
 	}
-	return jobAmount
+	body, headers := setPostBody(insert, param, result.Request.PostBodyOriginal, result.Request.HeadersOriginal)
+	return request.RequestSettings{
+		RequestId:    result.RequestId,
+		TargetHashId: result.TargetHashId,
+		Tag:          result.Tag,
+		Payload:      payload,
+		InsertPoints: insert.Points,
+		URLOriginal:  result.Request.URLOriginal,
+		Parameter:    param,
+		URL:          insert.SetURL(result.Request.URLOriginal),
+		Method:       result.Request.Method,
+		RequestBase: request.RequestBase{
+			Headers:              insert.SetHeaders(headers),
+			PostBody:             body,
+			HeadersOriginalArray: result.Request.HeadersOriginal,
+			PostBodyOriginal:     result.Request.PostBodyOriginal,
+		},
+	}
+}
+
+// Make an insert for each payload in the wordlist.
+// In case named insert points are used, the payload sets (related to the tag) for the insert points are used instead of the wordlist.
+func (r *Runner) makeInserts(tag string, wordlist []string) []insertpoint.Insert {
+	var inserts []insertpoint.Insert
+	if len(r.Conf.InsertPoints) > 0 {
+		for _, set := range r.Conf.AttackSets[tag] {
+			inserts = append(inserts, insertpoint.NewInsertPoints(r.Conf.Option.InsertKeyword, r.Conf.InsertPoints, set))
+		}
+		return inserts
+	}
+	for _, payload := range wordlist {
+		inserts = append(inserts, insertpoint.NewInsert(r.Conf.Option.InsertKeyword, payload))
+	}
+	return inserts
 }
 
 // Take a file containing user agents
 func getRandomUserAgent(file string) ([]string, error) {
 	content, err := ioutil.ReadFile(file)
 	if err != nil {
-		log.Fatalf("User-Agent file error :", err)
+		log.Fatalln("User-Agent file error :", err)
 	}
 	return strings.Split(string(content), "\n"), nil
 }
@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Brum3ns/firefly/internal/knowledge"
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/internal/ui"
+	"github.com/Brum3ns/firefly/pkg/httpprepare"
+	"github.com/Brum3ns/firefly/pkg/payloads"
+	"github.com/Brum3ns/firefly/pkg/statistics"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Store the results as knowledge about the targets (verify mode)
+type learntSink struct {
+	learnt map[string][]knowledge.Learnt
+	mutex  sync.Mutex
+}
+
+func newLearntSink() *learntSink {
+	return &learntSink{learnt: make(map[string][]knowledge.Learnt)}
+}
+
+func (s *learntSink) Open() error  { return nil }
+func (s *learntSink) Close() error { return nil }
+
+func (s *learntSink) Write(result output.ResultFinal) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.learnt[result.TargetHashId] = append(s.learnt[result.TargetHashId], knowledge.Learnt{
+		Payload:  result.Payload,
+		Extract:  result.Scanner.Extract,
+		HTMLNode: httpprepare.GetHTMLNode(result.Response.Body),
+		Response: result.Response,
+		// Note : (The calibration results are not used as knowledge of the target behavior)
+		Calibrate: result.Tag == payloads.TAG_CALIBRATE,
+	})
+	return nil
+}
+
+// Get the knowledge from all the stored results
+func (s *learntSink) Knowledge() map[string]knowledge.Knowledge {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return knowledge.GetKnowledge(s.learnt)
+}
+
+// Display the results to the screen (CLI) followed by the progress bar
+// In case the results are clustered, only the first result of each cluster is displayed and the clusters are displayed once the sink is closed.
+// Note : (Only the progress bar is shown in case the display is nil)
+type screenSink struct {
+	display     *output.Display
+	clusters    *output.Clusters
+	progressbar ui.ProgressBar
+}
+
+func newScreenSink(display *output.Display, clusters *output.Clusters, stats *statistics.Statistic) *screenSink {
+	return &screenSink{
+		display:     display,
+		clusters:    clusters,
+		progressbar: ui.NewProgressBar(100, stats),
+	}
+}
+
+func (s *screenSink) Open() error { return nil }
+
+func (s *screenSink) Write(result output.ResultFinal) error {
+	if s.display != nil && (s.clusters == nil || s.clusters.Add(result) == 1) {
+		s.display.ToScreen(result)
+	}
+	s.progressbar.Print()
+	return nil
+}
+
+func (s *screenSink) Close() error {
+	if s.display != nil && s.clusters != nil {
+		s.display.ClustersToScreen(s.clusters.List())
+	}
+	return nil
+}
+
+// Display the results and the statistics in the terminal UI
+type terminalUISink struct {
+	program *tea.Program
+	stats   *statistics.Statistic
+	wg      sync.WaitGroup
+}
+
+func newTerminalUISink(stats *statistics.Statistic) *terminalUISink {
+	return &terminalUISink{
+		program: ui.NewProgram(),
+		stats:   stats,
+	}
+}
+
+func (s *terminalUISink) Open() error {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if _, err := s.program.Run(); err != nil {
+			log.Fatalf("terminal UI - %s", err)
+		}
+	}()
+	return nil
+}
+
+func (s *terminalUISink) Write(result output.ResultFinal) error {
+	s.program.Send(*s.stats)
+	s.program.Send(result)
+	return nil
+}
+
+func (s *terminalUISink) Statistic(stats statistics.Statistic) {
+	s.program.Send(stats)
+}
+
+// Quit the terminal UI and wait until it's closed
+func (s *terminalUISink) Close() error {
+	s.program.Quit()
+	s.wg.Wait()
+	return nil
+}
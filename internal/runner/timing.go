@@ -0,0 +1,134 @@
+package runner
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Brum3ns/firefly/internal/config"
+	"github.com/Brum3ns/firefly/internal/knowledge"
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/internal/verbose"
+	"github.com/Brum3ns/firefly/pkg/request"
+	"github.com/Brum3ns/firefly/pkg/timing"
+)
+
+// The timing analyzer re-send the payloads that have a suspicious response time to confirm the timing anomaly (Ex: blind SQLi or command injection).
+// Payloads that contain a delay (Ex: "sleep(5)") are also sent with a scaled delay to confirm a linear relationship.
+type TimingAnalyzer struct {
+	baseline map[string]timing.Baseline
+	client   *http.Client
+	// Make the request of the result with another payload inserted (see: RebuildRequest)
+	rebuild func(output.ResultFinal, string) request.RequestSettings
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	closed  bool
+}
+
+// Make the timing analyzer with the response time baseline of each target (target hash|baseline).
+// The function "rebuild" make the request of a result with another payload inserted.
+func NewTimingAnalyzer(baseline map[string]timing.Baseline, client *http.Client, rebuild func(output.ResultFinal, string) request.RequestSettings) *TimingAnalyzer {
+	return &TimingAnalyzer{
+		baseline: baseline,
+		client:   client,
+		rebuild:  rebuild,
+	}
+}
+
+// Make the timing analyzer with the response time baseline of each target.
+// Return nil in case the timing analysis is not used or the runner is in verify mode.
+func newTimingAnalyzer(conf *config.Configure, knowledgeStorage map[string]knowledge.Knowledge) *TimingAnalyzer {
+	if !conf.Option.Timing || knowledgeStorage == nil {
+		return nil
+	}
+	baseline := make(map[string]timing.Baseline)
+	for hash, k := range knowledgeStorage {
+		var times []float64
+		for _, resp := range k.Responses {
+			times = append(times, resp.Time)
+		}
+		b := timing.NewBaseline(times, conf.Option.TimingThreshold)
+		baseline[hash] = b
+		verbose.Show(fmt.Sprintf("Timing baseline (%s): mean %.3fs, stddev %.3fs", hash, b.Mean, b.StdDev))
+	}
+	client := request.NewClient(request.ClientSettings{
+		Timeout: conf.Option.Timeout,
+		Proxy:   conf.Option.Proxy,
+		HTTP2:   conf.Option.HTTP2,
+	})
+	return NewTimingAnalyzer(baseline, client, func(result output.ResultFinal, payload string) request.RequestSettings {
+		return RebuildRequest(result, payload, conf.Option.InsertKeyword, conf.Option.Params[result.TargetHashId])
+	})
+}
+
+// Check if the response time of the result is suspicious and the result has not been analyzed yet
+func (a *TimingAnalyzer) Suspicious(result output.ResultFinal) bool {
+	b, ok := a.baseline[result.TargetHashId]
+	return ok && !result.Scanner.Timing.Checked && b.IsAnomaly(result.Response.Time)
+}
+
+// Analyze the result in the background and give it back to the channel once it's analyzed.
+// Return false in case the analyzer is closed (the result is not analyzed)
+func (a *TimingAnalyzer) Analyze(result output.ResultFinal, channel chan<- output.ResultFinal) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.closed {
+		return false
+	}
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		channel <- a.analyze(result)
+	}()
+	return true
+}
+
+// Close the analyzer and wait until all results are analyzed
+func (a *TimingAnalyzer) Close() {
+	a.mutex.Lock()
+	a.closed = true
+	a.mutex.Unlock()
+	a.wg.Wait()
+}
+
+func (a *TimingAnalyzer) analyze(result output.ResultFinal) output.ResultFinal {
+	var (
+		b       = a.baseline[result.TargetHashId]
+		first   = timing.Probe{Payload: result.Payload, Time: a.send(result, result.Payload)}
+		tResult = timing.Result{
+			Checked:  true,
+			Baseline: b.Mean,
+			Time:     result.Response.Time,
+		}
+	)
+	if scaled, delay, scaledDelay, ok := timing.ScalePayload(result.Payload, 2); ok {
+		first.Delay = delay
+		second := timing.Probe{Payload: scaled, Delay: scaledDelay, Time: a.send(result, scaled)}
+		tResult.Probes = []timing.Probe{first, second}
+		tResult.Linear = b.IsLinear(first, second)
+		tResult.OK = b.IsAnomaly(first.Time) && tResult.Linear
+	} else {
+		// The payload has no delay, the anomaly must be reproduced:
+		tResult.Probes = []timing.Probe{first}
+		tResult.OK = b.IsAnomaly(first.Time)
+	}
+
+	result.Scanner.Timing = tResult
+	if tResult.OK {
+		result.UnkownBehavior = true
+	}
+	return result
+}
+
+// Re-send the request of the result with another payload inserted into the same insert point(s). Return the response time (-1 in case of an error).
+func (a *TimingAnalyzer) send(result output.ResultFinal, payload string) float64 {
+	if len(result.Payload) == 0 {
+		return -1
+	}
+	resp := request.Request(a.client, a.rebuild(result, payload))
+	if resp.Error != nil {
+		verbose.Show(resp.Error)
+		return -1
+	}
+	return resp.Response.Time
+}
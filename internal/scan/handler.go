@@ -2,6 +2,9 @@ package scan
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
 
 	"github.com/Brum3ns/firefly/internal/config"
 	"github.com/Brum3ns/firefly/internal/knowledge"
@@ -96,7 +99,7 @@ func (e *Handler) Run(listener chan<- Result) {
 	// Listen a stop signal then wait until all background processes are completed:
 	if <-e.quit {
 		e.WaitGroup.Wait()
-		fmt.Println(":: Scanner handler stopped")
+		fmt.Fprintln(os.Stderr, ":: Scanner handler stopped")
 		return
 	}
 }
@@ -145,18 +148,22 @@ func makeResult(pResult scanResult) Result {
 			Tag:            pResult.Http.Tag,
 			Date:           pResult.Http.Date,
 			Payload:        pResult.Http.Payload,
+			InsertPoints:   pResult.Http.InsertPoints,
 			UnkownBehavior: pResult.UnkownBehavior,
 			OK:             true,
 
 			Request: output.Request{
-				URL:         req.RequestURI,
+				URL:         req.URL.String(),
 				URLOriginal: req.URLOriginal,
 				Host:        req.URL.Host,
 				Scheme:      req.URL.Scheme,
 				Method:      req.Method,
 				PostBody:    req.Body,
 				Proto:       req.Proto,
-				Headers:     req.HeadersOriginal,
+				Headers:     headersToArray(req.Header),
+
+				PostBodyOriginal: req.PostBodyOriginal,
+				HeadersOriginal:  req.HeadersOriginal,
 			},
 			Response: output.Response{
 				Time:          resp.Time,
@@ -185,3 +192,21 @@ func makeResult(pResult scanResult) Result {
 		Error: nil,
 	}
 }
+
+// Convert the headers that were sent in the request to a header array sorted by the header names
+func headersToArray(headers http.Header) [][2]string {
+	var (
+		arr   [][2]string
+		names []string
+	)
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			arr = append(arr, [2]string{name, value})
+		}
+	}
+	return arr
+}
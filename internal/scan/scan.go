@@ -9,6 +9,7 @@ import (
 	"github.com/Brum3ns/firefly/pkg/httpdiff"
 	"github.com/Brum3ns/firefly/pkg/httpprepare"
 	"github.com/Brum3ns/firefly/pkg/request"
+	"github.com/Brum3ns/firefly/pkg/simhash"
 	"github.com/Brum3ns/firefly/pkg/transformation"
 )
 
@@ -84,6 +85,10 @@ func (s scan) scan(job Job) scanResult {
 			ResultTransformation = s.Transformation(job)
 		}
 	}
+	// The similarity is used by the filter and match expressions even when the difference scan is not:
+	if s.Scanner.DisablesTechniques || !s.Scanner.OK_Diff {
+		ResultDifference.Similarity = s.Similarity(job)
+	}
 
 	//Confirm the unexpected behavior
 	if (job.OK_knowledge && !behavior.status) && (ResultDifference.OK || ResultExtract.OK || ResultTransformation.OK) {
@@ -141,7 +146,10 @@ func (s scan) Extract(job Job) extract.Result {
 			RegexHeaders:   ExtractMapDiff[1],
 			PatternBody:    ExtractMapDiff[2],
 			PatternHeaders: ExtractMapDiff[3],
+			Rules:          result.Rules,
+			Context:        result.Context,
 		}
+		result.Trim()
 	}
 	return result
 }
@@ -156,6 +164,7 @@ func (s scan) Difference(job Job) httpdiff.Result {
 			Compare: httpdiff.Compare{
 				HTMLMergeNode:   job.Knowledge.Combine.HTMLNode,
 				HeaderMergeNode: job.Knowledge.Combine.HeaderNode,
+				Simhash:         job.Knowledge.Simhash,
 			},
 			Randomness: s.Scanner.Randomness,
 			Filter:     s.Scanner.HttpDiffFilter,
@@ -163,15 +172,28 @@ func (s scan) Difference(job Job) httpdiff.Result {
 	)
 
 	headerResult := diff.GetHeadersDiff(httpprepare.GetHeaderNode(job.Http.Response.Header))
-	htmlResult := diff.GetHTMLNodeDiff(httpprepare.GetHTMLNode(job.Http.Response.Body))
+	htmlNode := httpprepare.GetHTMLNode(job.Http.Response.Body)
+	htmlResult := diff.GetHTMLNodeDiff(htmlNode)
+
+	// Tiny dynamic changes in a response that is similar to the known responses do not trigger the HTML difference:
+	similarity := diff.GetSimilarity(htmlNode)
+	if diff.IsSimilar(similarity) {
+		htmlResult.OK = false
+	}
 
 	return httpdiff.Result{
 		OK:           (headerResult.OK || htmlResult.OK),
 		HeaderResult: headerResult,
 		HTMLResult:   htmlResult,
+		Similarity:   similarity,
 	}
 }
 
+// Get the similarity of the response body compared to the most similar known response body
+func (s scan) Similarity(job Job) float64 {
+	return simhash.MaxSimilarity(simhash.FromHTMLNode(httpprepare.GetHTMLNode(job.Http.Response.Body)), job.Knowledge.Simhash)
+}
+
 // Scan for transformations within the payload
 func (s scan) Transformation(job Job) transformation.Result {
 	tfmt := s.Scanner.Transformation
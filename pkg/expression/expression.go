@@ -0,0 +1,256 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Expression is a compiled filter/match expression (Ex: `status in [200,302] && words > 50 && !body ~ "not found"`)
+// The expression is parsed once and can then be evaluated against any environment of field values.
+type Expression struct {
+	raw    string
+	root   node
+	fields map[string]struct{}
+}
+
+// The environment contains the values of the fields that can be used within an expression (field name|value).
+// Supported values: bool, int, float64, string, []string, map[string]string and map[string]int
+// Note : (The map values are indexed within the expression, Ex: `header["server"]`)
+type Env map[string]any
+
+// Compile the expression. An error is returned in case the expression has an invalid syntax.
+func Compile(s string) (*Expression, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, fields: make(map[string]struct{})}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+	return &Expression{raw: s, root: root, fields: p.fields}, nil
+}
+
+// Evaluate the expression with the given environment. Fields that are not within the environment have no value (false).
+func (e *Expression) Eval(env Env) bool {
+	return truthy(e.root.eval(env))
+}
+
+// Get all the field names used within the expression
+func (e *Expression) Fields() []string {
+	var lst []string
+	for name := range e.fields {
+		lst = append(lst, name)
+	}
+	sort.Strings(lst)
+	return lst
+}
+
+// Check if the expression use any of the given fields
+func (e *Expression) Uses(fields ...string) bool {
+	for _, name := range fields {
+		if _, ok := e.fields[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Expression) String() string {
+	return e.raw
+}
+
+// //////////// Nodes //////////// //
+
+type node interface {
+	eval(env Env) any
+}
+
+type literalNode struct{ value any }
+
+type listNode struct{ items []node }
+
+type fieldNode struct {
+	name string
+	key  node
+}
+
+type notNode struct{ x node }
+
+type logicNode struct {
+	and         bool
+	left, right node
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+	regex       *regexp.Regexp
+}
+
+func (n literalNode) eval(env Env) any { return n.value }
+
+func (n listNode) eval(env Env) any {
+	var lst []any
+	for _, i := range n.items {
+		lst = append(lst, i.eval(env))
+	}
+	return lst
+}
+
+func (n fieldNode) eval(env Env) any {
+	v, ok := env[n.name]
+	if !ok {
+		return nil
+	}
+	if n.key == nil {
+		return normalize(v)
+	}
+	key := fmt.Sprint(n.key.eval(env))
+	switch m := v.(type) {
+	case map[string]string:
+		if s, ok := m[key]; ok {
+			return s
+		}
+		return m[strings.ToLower(key)]
+	case map[string]int:
+		if i, ok := m[key]; ok {
+			return float64(i)
+		}
+		return float64(m[strings.ToLower(key)])
+	}
+	return nil
+}
+
+func (n notNode) eval(env Env) any { return !truthy(n.x.eval(env)) }
+
+func (n logicNode) eval(env Env) any {
+	if n.and {
+		return truthy(n.left.eval(env)) && truthy(n.right.eval(env))
+	}
+	return truthy(n.left.eval(env)) || truthy(n.right.eval(env))
+}
+
+func (n compareNode) eval(env Env) any {
+	a, b := n.left.eval(env), n.right.eval(env)
+	switch n.op {
+	case "==":
+		return equal(a, b)
+	case "!=":
+		return !equal(a, b)
+	case "~":
+		return n.regex.MatchString(toString(a))
+	case "!~":
+		return !n.regex.MatchString(toString(a))
+	case "in":
+		return contains(b, a)
+	case "contains":
+		return contains(a, b)
+	}
+
+	x, okA := a.(float64)
+	y, okB := b.(float64)
+	if !okA || !okB {
+		return false
+	}
+	switch n.op {
+	case ">":
+		return x > y
+	case ">=":
+		return x >= y
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	}
+	return false
+}
+
+// //////////// Values //////////// //
+
+// Convert the value to the types used within the evaluation (all numbers are float64)
+func normalize(v any) any {
+	switch i := v.(type) {
+	case int:
+		return float64(i)
+	case int64:
+		return float64(i)
+	case []string:
+		var lst []any
+		for _, s := range i {
+			lst = append(lst, s)
+		}
+		return lst
+	}
+	return v
+}
+
+func truthy(v any) bool {
+	switch i := v.(type) {
+	case bool:
+		return i
+	case float64:
+		return i != 0
+	case string:
+		return len(i) > 0
+	case []any:
+		return len(i) > 0
+	case map[string]string:
+		return len(i) > 0
+	case map[string]int:
+		return len(i) > 0
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		return ok && x == y
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+	return a == nil && b == nil
+}
+
+// Check if the container (list, string or map) contains the value
+func contains(container, v any) bool {
+	switch c := container.(type) {
+	case []any:
+		for _, i := range c {
+			if equal(i, v) {
+				return true
+			}
+		}
+	case string:
+		return strings.Contains(c, toString(v))
+	case map[string]string:
+		_, ok := c[strings.ToLower(toString(v))]
+		return ok
+	case map[string]int:
+		_, ok := c[toString(v)]
+		return ok
+	}
+	return false
+}
+
+func toString(v any) string {
+	switch i := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return i
+	}
+	return fmt.Sprint(v)
+}
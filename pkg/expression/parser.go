@@ -0,0 +1,291 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	tokenEOF = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	typ   int
+	value string
+	pos   int
+}
+
+// Operators sorted by length to match the longest operator first
+var operators = []string{"&&", "||", "==", "!=", ">=", "<=", "!~", ">", "<", "~", "!", "(", ")", "[", "]", ","}
+
+// Keywords that are used as operators
+var keywords = map[string]string{
+	"and":      "&&",
+	"or":       "||",
+	"not":      "!",
+	"in":       "in",
+	"contains": "contains",
+}
+
+func lex(s string) ([]token, error) {
+	var (
+		tokens []token
+		i      int
+	)
+	for i < len(s) {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			end := i + 1
+			for ; end < len(s) && s[end] != byte(c); end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			value := s[i+1 : end]
+			if c == '"' {
+				unquoted, err := strconv.Unquote(s[i : end+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %s", i, err)
+				}
+				value = unquoted
+			}
+			tokens = append(tokens, token{typ: tokenString, value: value, pos: i})
+			i = end + 1
+
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(s) && unicode.IsDigit(rune(s[i+1]))):
+			end := i
+			for end < len(s) && (unicode.IsDigit(rune(s[end])) || s[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{typ: tokenNumber, value: s[i:end], pos: i})
+			i = end
+
+		case unicode.IsLetter(c) || c == '_':
+			end := i
+			for end < len(s) && (unicode.IsLetter(rune(s[end])) || unicode.IsDigit(rune(s[end])) || strings.ContainsRune("_.-", rune(s[end]))) {
+				end++
+			}
+			word := s[i:end]
+			if op, ok := keywords[strings.ToLower(word)]; ok {
+				tokens = append(tokens, token{typ: tokenOperator, value: op, pos: i})
+			} else {
+				tokens = append(tokens, token{typ: tokenIdent, value: word, pos: i})
+			}
+			i = end
+
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, token{typ: tokenOperator, value: op, pos: i})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{typ: tokenEOF, value: "end of expression", pos: len(s)}), nil
+}
+
+// The parser use the following grammar (lowest to highest precedence):
+//
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = primary [ ( "==" | "!=" | ">" | ">=" | "<" | "<=" | "~" | "!~" | "in" | "contains" ) primary ]
+//	primary = number | string | "true" | "false" | list | field [ "[" primary "]" ] | "(" or ")"
+type parser struct {
+	tokens []token
+	pos    int
+	fields map[string]struct{}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// Consume the operator if it's the next token
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.typ == tokenOperator && t.value == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return fmt.Errorf("expected %q but got %q at position %d", op, t.value, t.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{x: x}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.typ != tokenOperator {
+		return left, nil
+	}
+	switch t.value {
+	case "==", "!=", ">", ">=", "<", "<=", "~", "!~", "in", "contains":
+		p.next()
+	default:
+		return left, nil
+	}
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	n := compareNode{op: t.value, left: left, right: right}
+
+	// The regex is compiled once and must be a string:
+	if n.op == "~" || n.op == "!~" {
+		lit, ok := right.(literalNode)
+		s, isString := lit.value.(string)
+		if !ok || !isString {
+			return nil, fmt.Errorf("the operator %q at position %d must be followed by a regex string", t.value, t.pos)
+		}
+		if n.regex, err = regexp.Compile(s); err != nil {
+			return nil, fmt.Errorf("invalid regex at position %d: %s", t.pos, err)
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.value, t.pos)
+		}
+		return literalNode{value: f}, nil
+
+	case tokenString:
+		return literalNode{value: t.value}, nil
+
+	case tokenIdent:
+		switch strings.ToLower(t.value) {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		}
+		n := fieldNode{name: strings.ToLower(t.value)}
+		p.fields[n.name] = struct{}{}
+		if p.accept("[") {
+			key, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n.key = key
+		}
+		return n, nil
+
+	case tokenOperator:
+		switch t.value {
+		case "(":
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+
+		case "[":
+			var lst listNode
+			if p.accept("]") {
+				return lst, nil
+			}
+			for {
+				item, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				lst.items = append(lst.items, item)
+				if p.accept("]") {
+					return lst, nil
+				}
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+}
@@ -2,32 +2,35 @@ package extract
 
 import (
 	"bufio"
-	"io/ioutil"
+	"fmt"
 	"log"
 	"os"
 	"regexp"
 	"strings"
-	"sync"
-)
 
-var (
-	WILDCARD = "WILDCARD"
+	"github.com/Brum3ns/firefly/pkg/snippet"
 )
 
 type Extract struct {
 	Properties
-	jobAmount int
-	sources   map[string]string                              //(Body|Headers)
-	fn_check  map[string]func(item, stringToTest string) int //Note : (contains the pattern/regex "check" function)
-
-	//Known Result //<- Known Patterns/Regex that have been discovered
+	sources map[string]string //(Body|Headers)
+	// Note : (The matchers are built once and shared by all copies of the structure)
+	body    *sourceMatcher
+	headers *sourceMatcher
+	// The rule of each pattern/regex (item|rule)
+	rules map[string]Rule
 }
 
 type Properties struct {
-	Threads         int
-	PrefixPatterns  []string
-	WordlistPattern map[string][]string //Inc: key="A shared prefix that all words in the list have has", value="wordlist itself"
-	WordlistRegex   map[string][]string // -/-
+	Rules []Rule
+	// Amount of characters of context taken on each side of the matches (zero to not take any context)
+	Context int
+}
+
+// The pattern matcher and the compiled regex of the rules used within a source (body or headers)
+type sourceMatcher struct {
+	patterns *matcher
+	regex    []*regexp.Regexp
 }
 
 type Result struct {
@@ -37,6 +40,10 @@ type Result struct {
 	PatternHeaders map[string]int
 	RegexBody      map[string]int
 	RegexHeaders   map[string]int
+	// The rules of the patterns/regex that were found (item|rule)
+	Rules map[string]Rule `json:",omitempty"`
+	// Snippets of the first matches of each pattern/regex with the text around them (item|snippets)
+	Context map[string][]snippet.Snippet `json:",omitempty"`
 }
 
 // !Note : (MUST be the same name as the "Result")
@@ -47,30 +54,55 @@ type ResultCombine struct {
 	RegexHeaders   map[string][]int `json:"RegexHeaders"`
 }
 
-type process struct {
-	done      bool
-	ok        bool
-	hits      int
-	typ       string
-	method    string
-	foundItem string
-}
+// Build the pattern matchers and compile the regex of the rules. An error is returned in case a regex is invalid.
+// Note : (In case more than one rule use the same pattern/regex, the first rule is used)
+func NewExtract(p Properties) (Extract, error) {
+	var (
+		e = Extract{
+			Properties: p,
+			rules:      make(map[string]Rule),
+		}
+		compiled = make(map[string]*regexp.Regexp)
+	)
+	for _, source := range []string{"body", "headers"} {
+		var (
+			patterns []string
+			seen     = make(map[string]bool)
+			m        = &sourceMatcher{}
+		)
+		for _, rule := range p.Rules {
+			item := rule.Item()
+			if len(item) == 0 || seen[item] || !rule.matchSource(source) {
+				continue
+			}
+			seen[item] = true
+			if _, exist := e.rules[item]; !exist {
+				e.rules[item] = rule
+			}
 
-type job struct {
-	prefix   string
-	method   string
-	wordlist []string
-}
+			if len(rule.Pattern) > 0 {
+				patterns = append(patterns, item)
+				continue
+			}
+			re, ok := compiled[item]
+			if !ok {
+				var err error
+				if re, err = regexp.Compile(item); err != nil {
+					return Extract{}, fmt.Errorf("invalid extract regex %q (rule %q): %w", item, rule.Id, err)
+				}
+				compiled[item] = re
+			}
+			m.regex = append(m.regex, re)
+		}
+		m.patterns = newMatcher(patterns)
 
-func NewExtract(p Properties) Extract {
-	return Extract{
-		fn_check: map[string]func(item, stringToTest string) int{
-			"pattern": checkPattern,
-			"regex":   checkRegex,
-		},
-		jobAmount:  (len(p.WordlistPattern) + len(p.WordlistRegex)) * 2, // Note : ("2" : Is used because we have two sources to check (body/headers) )
-		Properties: p,
+		if source == "body" {
+			e.body = m
+		} else {
+			e.headers = m
+		}
 	}
+	return e, nil
 }
 
 func NewCombine() ResultCombine {
@@ -83,16 +115,14 @@ func NewCombine() ResultCombine {
 }
 
 func (e *Extract) AddJob(body, headers string) {
-	//e.Known = known
 	e.sources = map[string]string{
 		"body":    body,
 		"headers": headers,
 	}
 }
 
-// Extract all patterns that was found within the response body and/or the response headers from the current target response.
-// Want: "Threads to use for pattern extracation", sources: "array of 2 {body, headers}", givenData: "Extract structure".
-// Return: status, amountTotal, amountType, headersPatterns, bodyPatterns.
+// Extract all patterns and regex that was found within the response body and/or the response headers from the current target response.
+// The patterns are counted by the number of times they appear while a regex that match count as one hit.
 func (e Extract) Run() Result {
 	result := Result{
 		TotalHits:      0,
@@ -100,89 +130,84 @@ func (e Extract) Run() Result {
 		PatternHeaders: make(map[string]int),
 		RegexBody:      make(map[string]int),
 		RegexHeaders:   make(map[string]int),
+		Rules:          make(map[string]Rule),
+		Context:        make(map[string][]snippet.Snippet),
 	}
 
-	//Check if the sources empty, if so, then end:
-	if len(e.sources["body"]) == 0 || len(e.sources["headers"]) == 0 {
-		return result
+	var limit int
+	if e.Context > 0 {
+		limit = snippet.LIMIT
 	}
-
-	var (
-		wg             sync.WaitGroup
-		processChannel = make(chan process)
-		JobQueue       = make(chan job)
-	)
-
-	for i := 0; i < e.Threads; i++ {
-		go e.analyze(&wg, JobQueue, processChannel)
-	}
-
-	go func(wg *sync.WaitGroup) {
-		var mutex sync.Mutex
-		for {
-			r := <-processChannel
-
-			if r.ok {
-				result.TotalHits += r.hits
-				if r.typ == "body" {
-					//Check method for body:
-					if r.method == "pattern" {
-						mutex.Lock()
-						result.PatternBody[r.foundItem] = r.hits
-						mutex.Unlock()
-
-					} else if r.method == "regex" {
-						mutex.Lock()
-						result.RegexBody[r.foundItem] = r.hits
-						mutex.Unlock()
-					}
-				} else if r.typ == "headers" {
-					//Check method for headers:
-					if r.method == "pattern" {
-						mutex.Lock()
-						result.PatternHeaders[r.foundItem] = r.hits
-						mutex.Unlock()
-
-					} else if r.method == "regex" {
-						mutex.Lock()
-						result.RegexHeaders[r.foundItem] = r.hits
-						mutex.Unlock()
-					}
-				}
-
-			} else if r.done {
-				wg.Done()
+	for _, src := range []struct {
+		name    string
+		s       string
+		m       *sourceMatcher
+		pattern map[string]int
+		regex   map[string]int
+	}{
+		{"body", e.sources["body"], e.body, result.PatternBody, result.RegexBody},
+		{"headers", e.sources["headers"], e.headers, result.PatternHeaders, result.RegexHeaders},
+	} {
+		if len(src.s) == 0 || src.m == nil {
+			continue
+		}
+		found, indexes := src.m.patterns.Find(src.s, limit)
+		for item, hits := range found {
+			src.pattern[item] = hits
+			result.Rules[item] = e.rules[item]
+			result.TotalHits += hits
+			if limit > 0 {
+				result.addContext(item, snippet.FromIndexes(src.name, src.s, indexes[item], e.Context))
 			}
 		}
-	}(&wg)
-
-	e.appendJobs(&wg, JobQueue)
-
-	//Wait for all processes to end:
-	wg.Wait()
+		for _, re := range src.m.regex {
+			item := re.String()
+			indexes := re.FindAllStringIndex(src.s, max(limit, 1))
+			if indexes == nil {
+				continue
+			}
+			if limit > 0 {
+				result.addContext(item, snippet.FromIndexes(src.name, src.s, indexes, e.Context))
+			}
+			src.regex[item] = 1
+			result.Rules[item] = e.rules[item]
+			result.TotalHits++
+		}
+	}
 
 	//Provide success status
 	if result.TotalHits > 0 {
 		result.OK = true
 	}
-
 	return result
 }
 
-// Give job to the extract process
-func (e *Extract) appendJobs(wg *sync.WaitGroup, j chan<- job) {
-	m := map[string]map[string][]string{
-		"pattern": e.WordlistPattern,
-		"regex":   e.WordlistRegex,
+// Add the snippets of the item (at most "snippet.LIMIT" snippets are kept for each item)
+func (r *Result) addContext(item string, lst []snippet.Snippet) {
+	for _, i := range lst {
+		if len(r.Context[item]) >= snippet.LIMIT {
+			return
+		}
+		r.Context[item] = append(r.Context[item], i)
 	}
-	for _, mt := range []string{"pattern", "regex"} {
-		for pfx, wl := range m[mt] { //Note : (Just extracting the map - key/value)
-			wg.Add(1)
-			j <- job{
-				prefix:   pfx,
-				method:   mt,
-				wordlist: wl,
-			}
+}
+
+// Remove the rules and the context of the items that are no longer within the result maps (Ex: once the known items are removed)
+func (r *Result) Trim() {
+	keep := make(map[string]bool)
+	for _, m := range []map[string]int{r.PatternBody, r.PatternHeaders, r.RegexBody, r.RegexHeaders} {
+		for item := range m {
+			keep[item] = true
+		}
+	}
+	for item := range r.Rules {
+		if !keep[item] {
+			delete(r.Rules, item)
+		}
+	}
+	for item := range r.Context {
+		if !keep[item] {
+			delete(r.Context, item)
 		}
 	}
 }
@@ -209,8 +234,9 @@ func GetUnique(current map[string]int, known map[string][]int, payload string) (
 		}
 		if uniuqe && !strings.Contains(payload, item) {
 			hit += ValueCurrent
+		} else {
+			delete(current, item)
 		}
-		delete(current, item)
 	}
 	return current, hit
 }
@@ -238,118 +264,19 @@ func GetMultiUnique(current []map[string]int, known []map[string][]int, payload
 	return storageDiff, totalHits
 }
 
-// Check for pattern inside the response body & headers:
-// This function uses a prefix technique that takes advantage of patterns that share the same prefix to provide a faster and more lightweight analyze.
-func (e *Extract) analyze(wg *sync.WaitGroup, jobs <-chan job, result chan<- process) {
-	for j := range jobs {
-		for _, t := range []string{"body", "headers"} {
-			stringToTest := e.sources[t]
-			if strings.Contains(stringToTest, j.prefix) {
-				//A new wordlist is in need of being analyzed. Add the wordlist length to the listener:
-				for _, item := range j.wordlist {
-
-					//Calculate how many times the item was within the content source.
-					//Then check if the item is a common (known behavior), if not, then send it to the listener:
-					if hits := e.fn_check[j.method](item, stringToTest); hits > 0 {
-						result <- process{
-							ok:        true,
-							typ:       t,
-							hits:      hits,
-							foundItem: item,
-							method:    j.method,
-						}
-					}
-				}
-			}
-		}
-		result <- process{done: true}
-	}
-}
-
-// Regex, string - check regex:
-func checkRegex(re, s string) int {
-	if match, _ := regexp.MatchString(re, s); match {
-		return 1
-	}
-	return 0
-}
-
-// Pattern, string - check amount of pattern in string:
-func checkPattern(ptn, s string) int {
-	if s == "" {
-		return 0
-	}
-	return strings.Count(s, ptn)
-}
-
-// Create a map from words within a list.
-// Return a list of all the shared prefix and a map. The key of the map is a prefix of all the words associated within the words added to the map list.
-// Note : (The list that only contain prefix is used for better preformance within loops.)
-func CreatePrefixMap(lst []string) ([]string, map[string][]string) {
-	var (
-		m        = make(map[string][]string)
-		lst_pfx  []string
-		wildcard bool
-	)
-	for _, i := range lst {
-		k := i[:3]
-		m[k] = append(m[k], i)
-	}
-	for k, l := range m {
-		if len(l) == 1 {
-			wildcard = true
-			//Add all alone items into a wildcard key ("WILDCARD"):
-			//Note : This words only if the max prefix for the other items are set to 3 in length.
-			m[WILDCARD] = append(m[WILDCARD], k)
-
-			//Delete the old list with only one item:
-			delete(m, k)
-		} else {
-			lst_pfx = append(lst_pfx, k)
-		}
-	}
-	//If the map contained wildcard prefix, then add it at the end:
-	if wildcard {
-		lst_pfx = append(lst_pfx, WILDCARD)
-	}
-
-	return lst_pfx, m
-}
-
-// Take a folder that have files (wordlists) with a prefix of: "ptn_" (pattern) OR "_re" (regex).
-// Return two wordlist : (Patterns|Regex)
-func MakeWordlists(folder string) ([]string, []string) {
-	var lst_files []string
-	//Read the files from the directory, If there is atleast one *file* found start adding the names to a list:
-	filesFolders, _ := ioutil.ReadDir(folder)
-	for _, f := range filesFolders {
-		if !f.IsDir() {
-			lst_files = append(lst_files, f.Name())
-		}
+// Read the non-empty lines of a file
+func readLines(path string) []string {
+	var lst []string
+	f, err := os.Open(path)
+	if err != nil {
+		return lst
 	}
-
-	wordlists := make(map[string][]string)
-	for _, f := range lst_files {
-		fpath := (folder + f)
-		typ := ""
-		if strings.HasPrefix(f, "ptn_") {
-			typ = "ptn"
-		} else if strings.HasPrefix(f, "re_") {
-			typ = "re"
-		} else { //Simply ignore the other files that miss the prefix
-			continue
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if item := scanner.Text(); len(item) > 0 {
+			lst = append(lst, item)
 		}
-		//Read file and append each item to the map "wordlists":
-		fcontent, _ := os.Open(fpath)
-		scanner := bufio.NewScanner(fcontent)
-		for scanner.Scan() {
-			item := scanner.Text()
-			if len(item) > 0 {
-				wordlists[typ] = append(wordlists[typ], item)
-			}
-		}
-		fcontent.Close()
 	}
-
-	return wordlists["ptn"], wordlists["re"]
+	return lst
 }
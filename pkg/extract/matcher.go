@@ -0,0 +1,108 @@
+package extract
+
+// Multi-pattern matcher (Aho-Corasick automaton) that find all the patterns within a string in one pass.
+// The automaton is built once and can be used concurrently since it's never modified once built.
+type matcher struct {
+	nodes    []node
+	patterns []string
+}
+
+type node struct {
+	next map[byte]int
+	fail int
+	// Index of the patterns that end at this node (including the patterns of the fail nodes)
+	output []int
+}
+
+// Build the automaton of the patterns (empty and duplicated patterns are ignored)
+func newMatcher(patterns []string) *matcher {
+	m := &matcher{nodes: []node{{next: make(map[byte]int)}}}
+	seen := make(map[string]bool)
+	for _, ptn := range patterns {
+		if len(ptn) == 0 || seen[ptn] {
+			continue
+		}
+		seen[ptn] = true
+		m.add(ptn)
+	}
+	m.build()
+	return m
+}
+
+func (m *matcher) add(ptn string) {
+	cur := 0
+	for i := 0; i < len(ptn); i++ {
+		c := ptn[i]
+		nxt, ok := m.nodes[cur].next[c]
+		if !ok {
+			nxt = len(m.nodes)
+			m.nodes = append(m.nodes, node{next: make(map[byte]int)})
+			m.nodes[cur].next[c] = nxt
+		}
+		cur = nxt
+	}
+	m.nodes[cur].output = append(m.nodes[cur].output, len(m.patterns))
+	m.patterns = append(m.patterns, ptn)
+}
+
+// Set the fail links (breadth first) so that the longest proper suffix that is also a prefix of a pattern is followed on a mismatch
+func (m *matcher) build() {
+	var queue []int
+	for _, nxt := range m.nodes[0].next {
+		queue = append(queue, nxt)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for c, nxt := range m.nodes[cur].next {
+			fail := m.nodes[cur].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[c]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if f, ok := m.nodes[fail].next[c]; ok && f != nxt {
+				m.nodes[nxt].fail = f
+			}
+			m.nodes[nxt].output = append(m.nodes[nxt].output, m.nodes[m.nodes[nxt].fail].output...)
+			queue = append(queue, nxt)
+		}
+	}
+}
+
+// Find the occurrences of all the patterns within the string. Return the count of each pattern and the byte offsets of its first occurrences (at most "limit").
+// Note : (Occurrences of the same pattern don't overlap, the same as "strings.Count")
+func (m *matcher) Find(s string, limit int) (map[string]int, map[string][][]int) {
+	var (
+		found   = make(map[string]int)
+		indexes = make(map[string][][]int)
+		// End of the last counted occurrence of each pattern
+		last = make(map[int]int)
+		cur  = 0
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		for cur > 0 {
+			if _, ok := m.nodes[cur].next[c]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		cur = m.nodes[cur].next[c]
+
+		for _, idx := range m.nodes[cur].output {
+			ptn := m.patterns[idx]
+			start := i + 1 - len(ptn)
+			if end, ok := last[idx]; ok && start < end {
+				continue
+			}
+			last[idx] = i + 1
+			found[ptn]++
+			if len(indexes[ptn]) < limit {
+				indexes[ptn] = append(indexes[ptn], []int{start, i + 1})
+			}
+		}
+	}
+	return found, indexes
+}
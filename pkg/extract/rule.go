@@ -0,0 +1,171 @@
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+)
+
+// Where a rule is matched within the response
+const (
+	MATCH_BODY   = "body"
+	MATCH_HEADER = "header"
+	MATCH_BOTH   = "both"
+)
+
+var (
+	// Severities of the rules (lowest to highest)
+	SEVERITIES = []string{"info", "low", "medium", "high", "critical"}
+
+	// Category of the rules that have no category (Ex: the plain "ptn_"/"re_" wordlists without a name)
+	CATEGORY_UNKNOWN = "unknown"
+)
+
+// A detection rule is a pattern or a regex with metadata about what it detects.
+//
+// Rule files are YAML files in the detection folder with the prefix "rules_" (Ex: "rules_sql.yml"):
+//
+//   - id: oracle-ora-00933
+//     pattern: "ORA-00933"
+//     category: sql-error
+//     severity: high
+//     match: body
+//     cwe: CWE-209
+type Rule struct {
+	Id       string `yaml:"id" json:"Id"`
+	Category string `yaml:"category" json:"Category"`
+	Severity string `yaml:"severity" json:"Severity"`
+	// Where the rule is matched: body, header or both (default)
+	Match   string `yaml:"match" json:"Match"`
+	CWE     string `yaml:"cwe" json:"CWE,omitempty"`
+	Pattern string `yaml:"pattern" json:"Pattern,omitempty"`
+	Regex   string `yaml:"regex" json:"Regex,omitempty"`
+}
+
+// Get the pattern or the regex of the rule (the same as the keys used within the result)
+func (r Rule) Item() string {
+	if len(r.Regex) > 0 {
+		return r.Regex
+	}
+	return r.Pattern
+}
+
+// Describe the rule by its metadata (Ex: "sql-error oracle-ora-00933, severity high, CWE-209")
+func (r Rule) String() string {
+	s := r.Category
+	if r.Id != r.Item() {
+		s += " " + r.Id
+	}
+	s += ", severity " + r.Severity
+	if len(r.CWE) > 0 {
+		s += ", " + r.CWE
+	}
+	return s
+}
+
+// Check if the rule is matched within the source (body or headers)
+func (r Rule) matchSource(source string) bool {
+	return r.Match == MATCH_BOTH || (r.Match == MATCH_BODY && source == "body") || (r.Match == MATCH_HEADER && source == "headers")
+}
+
+// Validate the rule and set the default values of the properties that are not set
+func (r *Rule) normalize() error {
+	if (len(r.Pattern) > 0) == (len(r.Regex) > 0) {
+		return fmt.Errorf("the rule %q must have either a pattern or a regex", r.Id)
+	}
+	if len(r.Id) == 0 {
+		r.Id = r.Item()
+	}
+	if len(r.Category) == 0 {
+		r.Category = CATEGORY_UNKNOWN
+	}
+	r.Severity = strings.ToLower(r.Severity)
+	if len(r.Severity) == 0 {
+		r.Severity = SEVERITIES[0]
+	} else if !slices.Contains(SEVERITIES, r.Severity) {
+		return fmt.Errorf("the rule %q has an invalid severity %q (%s)", r.Id, r.Severity, strings.Join(SEVERITIES, ", "))
+	}
+	switch r.Match = strings.ToLower(r.Match); r.Match {
+	case "":
+		r.Match = MATCH_BOTH
+	case "headers":
+		r.Match = MATCH_HEADER
+	case MATCH_BODY, MATCH_HEADER, MATCH_BOTH:
+	default:
+		return fmt.Errorf("the rule %q has an invalid match %q (body, header, both)", r.Id, r.Match)
+	}
+	return nil
+}
+
+// Make rules without metadata from a list of patterns and regex (Ex: the "ptn_" and "re_" wordlists)
+func WordlistRules(category string, patterns, regex []string) []Rule {
+	var rules []Rule
+	for _, ptn := range patterns {
+		rules = append(rules, Rule{Id: ptn, Category: category, Severity: SEVERITIES[0], Match: MATCH_BOTH, Pattern: ptn})
+	}
+	for _, re := range regex {
+		rules = append(rules, Rule{Id: re, Category: category, Severity: SEVERITIES[0], Match: MATCH_BOTH, Regex: re})
+	}
+	return rules
+}
+
+// Parse the rules from a YAML rule file
+func ParseRules(data []byte) ([]Rule, error) {
+	var rules []Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if err := rules[i].normalize(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// Load all the rules within the detection folder. The YAML rule files ("rules_*.yml") and the "ptn_"/"re_" wordlists are used.
+// The category of the wordlist items is the name of the wordlist (Ex: "ptn_sql-error.txt" => "sql-error").
+func LoadRules(folder string) ([]Rule, error) {
+	var rules []Rule
+	entries, _ := os.ReadDir(folder)
+	for _, f := range entries {
+		name := f.Name()
+		if f.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(name, "rules_") && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")) {
+			data, err := os.ReadFile(filepath.Join(folder, name))
+			if err != nil {
+				return nil, err
+			}
+			lst, err := ParseRules(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			rules = append(rules, lst...)
+		}
+	}
+
+	// The plain wordlists are added after the rule files, the first rule of an item is used in case it's duplicated:
+	for _, f := range entries {
+		name := f.Name()
+		if f.IsDir() || !(strings.HasPrefix(name, "ptn_") || strings.HasPrefix(name, "re_")) {
+			continue
+		}
+		category := strings.TrimSuffix(strings.SplitN(name, "_", 2)[1], filepath.Ext(name))
+		if len(category) == 0 {
+			category = CATEGORY_UNKNOWN
+		}
+		items := readLines(filepath.Join(folder, name))
+		if strings.HasPrefix(name, "ptn_") {
+			rules = append(rules, WordlistRules(category, items, nil)...)
+		} else {
+			rules = append(rules, WordlistRules(category, nil, items)...)
+		}
+	}
+	return rules, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Brum3ns/firefly/pkg/httpprepare"
 	"github.com/Brum3ns/firefly/pkg/randomness"
+	"github.com/Brum3ns/firefly/pkg/simhash"
 )
 
 type Difference struct {
@@ -22,12 +23,17 @@ type Config struct {
 type Compare struct {
 	HeaderMergeNode httpprepare.Header
 	HTMLMergeNode   httpprepare.HTMLNodeCombine
+	// Simhash of the known HTTP response bodies
+	Simhash []uint64
 }
 
+// Note : (The embedded results have their own JSON names since they share the same field names)
 type Result struct {
-	OK bool
-	HeaderResult
-	HTMLResult
+	OK           bool
+	HeaderResult `json:"Header"`
+	HTMLResult   `json:"HTML"`
+	// Similarity (0-1) of the response body compared to the most similar known response body
+	Similarity float64 `json:"Similarity"`
 }
 
 type HeaderResult struct {
@@ -56,6 +62,8 @@ type HTMLNodeDiff struct {
 type Filter struct {
 	HeaderFilter
 	//HTMLFilter
+	// The HTML difference is ignored in case the similarity is the same or above the threshold (zero to disable)
+	SimilarityThreshold float64
 }
 
 type HeaderFilter struct {
@@ -80,6 +88,16 @@ func newDiffNode() diffNode {
 	}
 }
 
+// Get the similarity of the HTML node compared to the most similar known response body
+func (diff *Difference) GetSimilarity(htmlNode httpprepare.HTMLNode) float64 {
+	return simhash.MaxSimilarity(simhash.FromHTMLNode(htmlNode), diff.Config.Compare.Simhash)
+}
+
+// Check if the similarity is high enough for the HTML difference to be seen as a tiny dynamic change
+func (diff *Difference) IsSimilar(similarity float64) bool {
+	return diff.Config.Filter.SimilarityThreshold > 0 && similarity >= diff.Config.Filter.SimilarityThreshold
+}
+
 // Run the [diff]erence enumiration process for the HTML node
 func (diff *Difference) GetHTMLNodeDiff(htmlNode httpprepare.HTMLNode) HTMLResult {
 	totalHits := 0
@@ -0,0 +1,82 @@
+package httpfilter
+
+import "fmt"
+
+// The calibration filter match the default response of a target (Ex: catch-all pages).
+// It's made from the baseline responses of the target and use all the properties (size, words, lines) that are the same in all the baseline responses.
+type Calibration struct {
+	statusCodes map[int]struct{}
+	fields      []calibrationField
+	values      []int
+}
+
+type calibrationField struct {
+	name  string
+	value func(Response) int
+}
+
+// Properties of the response that can be used by the calibration filter
+var calibrationFields = []calibrationField{
+	{"size", func(r Response) int { return r.ResponseSize }},
+	{"words", func(r Response) int { return r.WordCount }},
+	{"lines", func(r Response) int { return r.LineCount }},
+}
+
+// Make a calibration filter from the baseline responses of a target.
+// Return false in case no property is the same in all the baseline responses (the target can't be calibrated).
+func NewCalibration(responses []Response) (Calibration, bool) {
+	if len(responses) == 0 {
+		return Calibration{}, false
+	}
+	c := Calibration{statusCodes: make(map[int]struct{})}
+	for _, resp := range responses {
+		c.statusCodes[resp.StatusCode] = struct{}{}
+	}
+
+	for _, f := range calibrationFields {
+		value, stable := f.value(responses[0]), true
+		for _, resp := range responses[1:] {
+			if f.value(resp) != value {
+				stable = false
+				break
+			}
+		}
+		if stable {
+			c.fields = append(c.fields, f)
+			c.values = append(c.values, value)
+		}
+	}
+	if len(c.fields) == 0 {
+		return Calibration{}, false
+	}
+	return c, true
+}
+
+// Check if the response is a default response of the target (the response should be filtered).
+// The status code and all the stable properties must match.
+func (c Calibration) Run(resp Response) bool {
+	if len(c.fields) == 0 {
+		return false
+	}
+	if _, ok := c.statusCodes[resp.StatusCode]; !ok {
+		return false
+	}
+	for idx, f := range c.fields {
+		if f.value(resp) != c.values[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Calibration) String() string {
+	var codes []int
+	for code := range c.statusCodes {
+		codes = append(codes, code)
+	}
+	s := fmt.Sprintf("status:%v", codes)
+	for idx, f := range c.fields {
+		s += fmt.Sprintf(" %s:%d", f.name, c.values[idx])
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+package httpfilter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Brum3ns/firefly/pkg/expression"
+)
+
+// Fields of the HTTP response that can be used within a filter/match expression
+var EXPRESSION_FIELDS = []string{"status", "size", "words", "lines", "time", "body", "headers", "header"}
+
+// Make the expression environment of the HTTP response
+func (resp Response) Env() expression.Env {
+	return expression.Env{
+		"status":  resp.StatusCode,
+		"size":    resp.ResponseSize,
+		"words":   resp.WordCount,
+		"lines":   resp.LineCount,
+		"time":    resp.ResponseTime,
+		"body":    string(resp.Body),
+		"headers": string(makeHeaderToBytes(resp.Headers)),
+		"header":  HeaderMap(resp.Headers),
+	}
+}
+
+// Make a map of the headers with the lowercase header name as key and the values separated by comma
+func HeaderMap(headers http.Header) map[string]string {
+	m := make(map[string]string, len(headers))
+	for name, values := range headers {
+		m[strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+	return m
+}
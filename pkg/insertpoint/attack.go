@@ -0,0 +1,144 @@
+package insertpoint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Attack strategies used when multiple (named) insert points are set:
+var (
+	// One insert point at a time is given the payloads while the others keep their original value
+	ATTACK_SNIPER = "sniper"
+	// The wordlists of all insert points are used in parallel (The first payload of all wordlists, then the second...)
+	ATTACK_PITCHFORK = "pitchfork"
+	// All combinations of the payloads in all the wordlists (cartesian product)
+	ATTACK_CLUSTERBOMB = "clusterbomb"
+	ATTACKS            = []string{ATTACK_SNIPER, ATTACK_PITCHFORK, ATTACK_CLUSTERBOMB}
+)
+
+// Point is a named insert point that have its own wordlist (Ex: "FUZZ1")
+type Point struct {
+	Keyword string
+	// The value used when the insert point is not attacked (sniper attack)
+	Original string
+	// Path to the wordlist
+	Wordlist string
+	Payloads []string
+}
+
+// Set holds the payload of each insert point to be used within a single request.
+// Note : (The payloads have the same order as the insert points that made the set)
+type Set struct {
+	Payloads []string
+	// The index of the insert point that is attacked. In case all insert points are attacked the value is -1
+	Attacked int
+}
+
+// Parse a named insert point in the format "{keyword}:{wordlist}[:{original value}]"
+func ParsePoint(s string) (Point, error) {
+	l := strings.SplitN(s, ":", 3)
+	if len(l) < 2 || len(l[0]) == 0 || len(l[1]) == 0 {
+		return Point{}, fmt.Errorf("invalid insert point \"%s\", the format is: {keyword}:{wordlist}[:{original value}]", s)
+	}
+	p := Point{
+		Keyword:  l[0],
+		Wordlist: l[1],
+	}
+	if len(l) == 3 {
+		p.Original = l[2]
+	}
+	return p, nil
+}
+
+// Make the payload sets for all the insert points based on the attack strategy.
+func Attack(strategy string, points []Point) ([]Set, error) {
+	var sets []Set
+	if len(points) == 0 {
+		return sets, errors.New("no insert points to attack")
+	}
+
+	switch strategy {
+	case ATTACK_SNIPER:
+		for idx, p := range points {
+			for _, payload := range p.Payloads {
+				set := Set{Payloads: originals(points), Attacked: idx}
+				set.Payloads[idx] = payload
+				sets = append(sets, set)
+			}
+		}
+
+	case ATTACK_PITCHFORK:
+		// Stop when the shortest wordlist is out of payloads:
+		amount := len(points[0].Payloads)
+		for _, p := range points[1:] {
+			amount = min(amount, len(p.Payloads))
+		}
+		for i := 0; i < amount; i++ {
+			set := Set{Attacked: -1}
+			for _, p := range points {
+				set.Payloads = append(set.Payloads, p.Payloads[i])
+			}
+			sets = append(sets, set)
+		}
+
+	case ATTACK_CLUSTERBOMB:
+		// Each new insert point is combined with all the sets made by the previous insert points:
+		combinations := [][]string{{}}
+		for _, p := range points {
+			var next [][]string
+			for _, c := range combinations {
+				for _, payload := range p.Payloads {
+					next = append(next, append(append([]string{}, c...), payload))
+				}
+			}
+			combinations = next
+		}
+		for _, c := range combinations {
+			sets = append(sets, Set{Payloads: c, Attacked: -1})
+		}
+
+	default:
+		return sets, fmt.Errorf("invalid attack strategy \"%s\", supported: %s", strategy, strings.Join(ATTACKS, ","))
+	}
+	return sets, nil
+}
+
+// Make a set where all the insert points are given the same payload
+func SameSet(points []Point, payload string) Set {
+	set := Set{Attacked: -1}
+	for range points {
+		set.Payloads = append(set.Payloads, payload)
+	}
+	return set
+}
+
+// Get the payload that represent the set. For a sniper attack it's the payload of the attacked insert point, otherwise the payload of the first insert point.
+func (s Set) Payload() string {
+	if s.Attacked >= 0 && s.Attacked < len(s.Payloads) {
+		return s.Payloads[s.Attacked]
+	} else if len(s.Payloads) > 0 {
+		return s.Payloads[0]
+	}
+	return ""
+}
+
+// Return a map of the insert point keywords and the payloads used in the set (keyword|payload)
+func (s Set) Map(points []Point) map[string]string {
+	m := make(map[string]string)
+	for idx, p := range points {
+		if idx < len(s.Payloads) {
+			m[p.Keyword] = s.Payloads[idx]
+		}
+	}
+	return m
+}
+
+// Get the original values of all insert points
+func originals(points []Point) []string {
+	var lst []string
+	for _, p := range points {
+		lst = append(lst, p.Original)
+	}
+	return lst
+}
@@ -0,0 +1,178 @@
+package insertpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	"github.com/Brum3ns/firefly/pkg/random"
+)
+
+const (
+	xmlCDATAStart = "<![CDATA["
+	xmlCDATAEnd   = "]]>"
+)
+
+// Insert the payload into a JSON post body. Payloads placed within a JSON string are escaped to keep the string valid (Ex: " => \")
+// Note : (Payloads outside of strings are inserted as raw values)
+func (ist Insert) SetJSONPostBody(s string) string {
+	var (
+		buf      strings.Builder
+		keywords = ist.keywords()
+		inString bool
+		escaped  bool
+	)
+	s = ist.randomInsert(s)
+
+	for i := 0; i < len(s); i++ {
+		if keyword, ok := matchKeyword(keywords, s[i:]); ok {
+			payload := ist.payload(keyword)
+			if inString {
+				payload = escapeJSONString(payload)
+			}
+			buf.WriteString(payload)
+			i += len(keyword) - 1
+			escaped = false
+			continue
+		}
+
+		c := s[i]
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String()
+}
+
+// Insert the payload into a XML post body. Payloads are XML escaped (Ex: < => &lt;).
+// Within a CDATA section the payload is kept as it is, except for the CDATA end ("]]>") that is split into two CDATA sections.
+func (ist Insert) SetXMLPostBody(s string) string {
+	var (
+		buf      strings.Builder
+		keywords = ist.keywords()
+		inCDATA  bool
+	)
+	s = ist.randomInsert(s)
+
+	for i := 0; i < len(s); i++ {
+		if keyword, ok := matchKeyword(keywords, s[i:]); ok {
+			payload := ist.payload(keyword)
+			if inCDATA {
+				payload = strings.ReplaceAll(payload, xmlCDATAEnd, "]]"+xmlCDATAEnd+xmlCDATAStart+">")
+			} else {
+				payload = escapeXML(payload)
+			}
+			buf.WriteString(payload)
+			i += len(keyword) - 1
+			continue
+		}
+
+		switch {
+		case !inCDATA && strings.HasPrefix(s[i:], xmlCDATAStart):
+			inCDATA = true
+		case inCDATA && strings.HasPrefix(s[i:], xmlCDATAEnd):
+			inCDATA = false
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}
+
+// Insert the payload into a multipart post body and return the body and the boundary used.
+// Payloads placed in the part headers (Ex: filename) have their quotes and line breaks encoded.
+// In case a payload contains the boundary, a new boundary is used that must replace the boundary in the content type header.
+func (ist Insert) SetMultipartPostBody(s, boundary string) (string, string) {
+	var (
+		buf         strings.Builder
+		keywords    = ist.keywords()
+		newBoundary = boundary
+		inHeader    bool
+	)
+	s = ist.randomInsert(s)
+
+	for ist.containBoundary(keywords, s, newBoundary) {
+		newBoundary = boundary + random.RandString(16)
+	}
+
+	for i := 0; i < len(s); i++ {
+		lineStart := (i == 0 || s[i-1] == '\n')
+
+		switch {
+		// Delimiter line, the headers of the part follows:
+		case lineStart && strings.HasPrefix(s[i:], "--"+boundary):
+			buf.WriteString("--" + newBoundary)
+			i += len(boundary) + 1
+			inHeader = true
+			continue
+
+		// An empty line ends the headers of the part:
+		case lineStart && inHeader && (strings.HasPrefix(s[i:], "\r\n") || strings.HasPrefix(s[i:], "\n")):
+			inHeader = false
+		}
+
+		if keyword, ok := matchKeyword(keywords, s[i:]); ok {
+			payload := ist.payload(keyword)
+			if inHeader {
+				payload = escapeMultipartHeader(payload)
+			}
+			buf.WriteString(payload)
+			i += len(keyword) - 1
+			continue
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String(), newBoundary
+}
+
+// Check if any of the payloads to insert into the string contains the boundary
+func (ist Insert) containBoundary(keywords []string, s, boundary string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) && strings.Contains(ist.payload(keyword), boundary) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the keyword that the string starts with (if any).
+// Note : (The keywords must be sorted by length, longest first)
+func matchKeyword(keywords []string, s string) (string, bool) {
+	for _, keyword := range keywords {
+		if strings.HasPrefix(s, keyword) {
+			return keyword, true
+		}
+	}
+	return "", false
+}
+
+// Escape a string to be placed within a JSON string (without the surrounding quotes)
+func escapeJSONString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSuffix(buf.String(), "\n"), `"`), `"`)
+}
+
+// Escape a string to be placed within a XML text or attribute value
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	if err := xml.EscapeText(&buf, []byte(s)); err != nil {
+		return s
+	}
+	return buf.String()
+}
+
+// Encode the characters that would break a multipart header value (Ex: filename="...")
+func escapeMultipartHeader(s string) string {
+	return strings.NewReplacer(`"`, "%22", "\r", "%0D", "\n", "%0A").Replace(s)
+}
@@ -2,6 +2,7 @@ package insertpoint
 
 import (
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/Brum3ns/firefly/pkg/random"
@@ -10,6 +11,10 @@ import (
 type Insert struct {
 	Keyword string
 	Payload string
+	// Named insert points and their payloads (keyword|payload)
+	Points map[string]string
+	// Make a new out-of-band host for the keyword "#OOB#" (see: OOBInsert)
+	oobHost func() string
 }
 
 // Take the "Insert" structure and the "Request" structure
@@ -21,23 +26,88 @@ func NewInsert(keyword, payload string) Insert {
 	}
 }
 
+// Take the named insert points and the payload set to be used within them.
+// Note : (The keyword (default=FUZZ) is still replaced with the payload that represent the set)
+func NewInsertPoints(keyword string, points []Point, set Set) Insert {
+	return Insert{
+		Keyword: keyword,
+		Payload: set.Payload(),
+		Points:  set.Map(points),
+	}
+}
+
+// Get a copy of the insert where the keyword "#OOB#" in the payloads and the request is replaced with a new out-of-band host made by "host".
+// Note : (Must be used for each request, so that each request get its own unique host)
+func (ist Insert) OOBInsert(host func() string) Insert {
+	ist.oobHost = host
+	ist.Payload = random.OOBInsert(ist.Payload, host)
+	if len(ist.Points) > 0 {
+		points := make(map[string]string, len(ist.Points))
+		for keyword, payload := range ist.Points {
+			points[keyword] = random.OOBInsert(payload, host)
+		}
+		ist.Points = points
+	}
+	return ist
+}
+
+// Insert the random values and the out-of-band hosts (if used) into the string
+func (ist Insert) randomInsert(s string) string {
+	return random.OOBInsert(random.RandomInsert(s), ist.oobHost)
+}
+
 // Insert the payload based on the insert point (default=FUZZ) from user options to a string
 func (ist Insert) addKeyword(s string) string {
-	return strings.ReplaceAll(random.RandomInsert(s), ist.Keyword, ist.Payload)
+	return ist.replacer(func(payload string) string { return payload }).Replace(ist.randomInsert(s))
+}
+
+// Make a replacer for the keyword and all the named insert points. The function "adapt" is used to adapt the payloads to the position (Ex: URL).
+func (ist Insert) replacer(adapt func(string) string) *strings.Replacer {
+	var oldnew []string
+	for _, keyword := range ist.keywords() {
+		oldnew = append(oldnew, keyword, adapt(ist.payload(keyword)))
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// Get the keyword and all the named insert point keywords.
+// Note : (Longer keywords are placed first, so that "FUZZ" dosen't break "FUZZ1")
+func (ist Insert) keywords() []string {
+	var keywords []string
+	if len(ist.Keyword) > 0 {
+		keywords = append(keywords, ist.Keyword)
+	}
+	for keyword := range ist.Points {
+		if keyword != ist.Keyword && len(keyword) > 0 {
+			keywords = append(keywords, keyword)
+		}
+	}
+	sort.SliceStable(keywords, func(i, j int) bool {
+		return len(keywords[i]) > len(keywords[j])
+	})
+	return keywords
+}
+
+// Get the payload to insert for the keyword
+func (ist Insert) payload(keyword string) string {
+	if payload, ok := ist.Points[keyword]; ok {
+		return payload
+	}
+	return ist.Payload
 }
 
 func (ist Insert) SetHeaders(sliceArry [][2]string) http.Header {
 	var headers = http.Header{}
 	for _, h := range sliceArry {
-		hName := random.RandomInsert(h[0])
-		hValue := random.RandomInsert(h[1])
+		hName := ist.randomInsert(h[0])
+		hValue := ist.randomInsert(h[1])
 		headers.Add(ist.addKeyword(hName), ist.addKeyword(hValue))
 	}
 	return headers
 }
 
 func (ist Insert) SetURL(s string) string {
-	return strings.ReplaceAll(random.RandomInsert(s), ist.Keyword, normalizeURLstring(ist.Payload))
+	return ist.replacer(normalizeURLstring).Replace(ist.randomInsert(s))
 }
 
 func (ist Insert) SetPostBody(s string) string {
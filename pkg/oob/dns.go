@@ -0,0 +1,118 @@
+package oob
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+)
+
+const (
+	dnsHeaderSize = 12
+	dnsTypeA      = 1
+	dnsClassIN    = 1
+	dnsTTL        = 60
+)
+
+// A DNS question (RFC 1035)
+type dnsQuestion struct {
+	Name  string
+	Type  uint16
+	Class uint16
+	// End of the question within the message
+	end int
+}
+
+// Serve the DNS queries until the connection is closed.
+// All the queries are answered (with the IP in the config for A queries), but only the queries for a known id are interactions.
+func (s *Server) serveDNS() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.dnsConn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		msg := append([]byte{}, buf[:n]...)
+		q, err := parseDNSQuestion(msg)
+		if err != nil {
+			continue
+		}
+		s.dnsConn.WriteTo(dnsAnswer(msg, q, net.ParseIP(s.config.IP)), addr)
+
+		if id, ok := s.lookupId(q.Name, ""); ok {
+			s.emit(Interaction{
+				Id:         id,
+				Protocol:   PROTOCOL_DNS,
+				RemoteAddr: addr.String(),
+				Query:      q.Name,
+			})
+		}
+	}
+}
+
+// Parse the first question of the DNS message
+func parseDNSQuestion(msg []byte) (dnsQuestion, error) {
+	var (
+		q      dnsQuestion
+		labels []string
+		i      = dnsHeaderSize
+	)
+	if len(msg) < dnsHeaderSize || binary.BigEndian.Uint16(msg[4:6]) == 0 {
+		return q, errors.New("invalid DNS message")
+	}
+	for {
+		if i >= len(msg) {
+			return q, errors.New("invalid DNS question")
+		}
+		l := int(msg[i])
+		i++
+		if l == 0 {
+			break
+		}
+		// Note : (Compression pointers are not used within the question of a query)
+		if l&0xC0 != 0 || i+l > len(msg) {
+			return q, errors.New("invalid DNS label")
+		}
+		labels = append(labels, string(msg[i:i+l]))
+		i += l
+	}
+	if i+4 > len(msg) {
+		return q, errors.New("invalid DNS question")
+	}
+	q.Name = strings.ToLower(strings.Join(labels, "."))
+	q.Type = binary.BigEndian.Uint16(msg[i : i+2])
+	q.Class = binary.BigEndian.Uint16(msg[i+2 : i+4])
+	q.end = i + 4
+	return q, nil
+}
+
+// Make the answer of the DNS query. A records are answered with the IP (if it's an IPv4) and all other queries have an empty answer.
+func dnsAnswer(msg []byte, q dnsQuestion, ip net.IP) []byte {
+	var (
+		ip4    = ip.To4()
+		answer = q.Type == dnsTypeA && q.Class == dnsClassIN && ip4 != nil
+		resp   = make([]byte, dnsHeaderSize, q.end+16)
+	)
+	// Header: same id, response + authoritative answer (the recursion desired flag is kept)
+	copy(resp[0:2], msg[0:2])
+	binary.BigEndian.PutUint16(resp[2:4], 0x8400|uint16(msg[2]&0x01)<<8)
+	binary.BigEndian.PutUint16(resp[4:6], 1)
+	if answer {
+		binary.BigEndian.PutUint16(resp[6:8], 1)
+	}
+	resp = append(resp, msg[dnsHeaderSize:q.end]...)
+
+	if answer {
+		// Pointer to the name in the question (offset 12)
+		resp = append(resp, 0xC0, dnsHeaderSize)
+		resp = binary.BigEndian.AppendUint16(resp, dnsTypeA)
+		resp = binary.BigEndian.AppendUint16(resp, dnsClassIN)
+		resp = binary.BigEndian.AppendUint32(resp, dnsTTL)
+		resp = binary.BigEndian.AppendUint16(resp, 4)
+		resp = append(resp, ip4...)
+	}
+	return resp
+}
@@ -0,0 +1,274 @@
+package oob
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PROTOCOL_DNS  = "dns"
+	PROTOCOL_HTTP = "http"
+)
+
+var (
+	// Length of the unique id used within the out-of-band hosts
+	ID_LENGTH = 16
+	// Characters of the unique id (DNS names are case insensitive)
+	idChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// Configuration of the out-of-band (OOB) server
+type Config struct {
+	// Domain that the DNS server is authoritative for (Ex: "oob.example.com"). In case no domain is set, the hosts are given as URLs to the HTTP server (Ex: "127.0.0.1:8080/{id}")
+	Domain string
+	// Address that the HTTP/DNS server bind to (Ex: ":80", "127.0.0.1:5353"). An empty address disables the server
+	HTTPAddress string
+	DNSAddress  string
+	// IP address given in the DNS answers and used in the hosts in case no domain is set
+	IP string
+}
+
+// An interaction is a DNS query or HTTP request received by the OOB server for a host that was given to a request
+type Interaction struct {
+	Id         string `json:"Id"`
+	RequestId  int    `json:"RequestId"`
+	Protocol   string `json:"Protocol"`
+	RemoteAddr string `json:"RemoteAddr"`
+	// The DNS name queried or the HTTP request line (Ex: "GET /path")
+	Query string `json:"Query"`
+	Raw   string `json:"Raw,omitempty"`
+	Date  string `json:"Date"`
+}
+
+// The OOB server listen for DNS and HTTP interactions with the unique hosts (see: Host) and give them to the "Interactions" channel.
+// Only the first interaction of each protocol is given for a host.
+type Server struct {
+	Interactions chan Interaction
+
+	config       Config
+	httpServer   *http.Server
+	httpListener net.Listener
+	dnsConn      net.PacketConn
+	// The unique ids and the request they are assigned to (id|request id)
+	ids    map[string]int
+	seen   map[string]bool
+	closed bool
+	mutex  sync.Mutex
+	wg     sync.WaitGroup
+	// The interactions that are given to the channel
+	emitting sync.WaitGroup
+}
+
+// Make the OOB server and bind the listeners (the server is started with "Start")
+func NewServer(config Config) (*Server, error) {
+	s := &Server{
+		Interactions: make(chan Interaction, 100),
+		config:       config,
+		ids:          make(map[string]int),
+		seen:         make(map[string]bool),
+	}
+	s.config.Domain = strings.Trim(strings.ToLower(config.Domain), ".")
+
+	if len(config.HTTPAddress) == 0 && len(config.DNSAddress) == 0 {
+		return nil, errors.New("no HTTP or DNS address to listen on")
+	}
+	if len(config.HTTPAddress) > 0 {
+		l, err := net.Listen("tcp", config.HTTPAddress)
+		if err != nil {
+			return nil, fmt.Errorf("OOB HTTP server: %w", err)
+		}
+		s.httpListener = l
+		s.httpServer = &http.Server{Handler: http.HandlerFunc(s.handleHTTP), ReadHeaderTimeout: 10 * time.Second}
+	}
+	if len(config.DNSAddress) > 0 {
+		conn, err := net.ListenPacket("udp", config.DNSAddress)
+		if err != nil {
+			if s.httpListener != nil {
+				s.httpListener.Close()
+			}
+			return nil, fmt.Errorf("OOB DNS server: %w", err)
+		}
+		s.dnsConn = conn
+	}
+	return s, nil
+}
+
+// Start to serve the listeners in the background
+func (s *Server) Start() {
+	if s.httpServer != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.httpServer.Serve(s.httpListener)
+		}()
+	}
+	if s.dnsConn != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveDNS()
+		}()
+	}
+}
+
+// Stop the listeners and close the interaction channel
+func (s *Server) Close() error {
+	var err error
+	if s.httpServer != nil {
+		err = s.httpServer.Close()
+	}
+	if s.dnsConn != nil {
+		err = errors.Join(err, s.dnsConn.Close())
+	}
+	s.wg.Wait()
+
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return err
+	}
+	s.closed = true
+	s.mutex.Unlock()
+
+	// The interactions must be given before the channel is closed:
+	s.emitting.Wait()
+	close(s.Interactions)
+	return err
+}
+
+// Get the address that the HTTP server listen on (empty if not used)
+func (s *Server) HTTPAddress() string {
+	if s.httpListener == nil {
+		return ""
+	}
+	return s.httpListener.Addr().String()
+}
+
+// Get the address that the DNS server listen on (empty if not used)
+func (s *Server) DNSAddress() string {
+	if s.dnsConn == nil {
+		return ""
+	}
+	return s.dnsConn.LocalAddr().String()
+}
+
+// Make a new unique host (Ex: "{id}.oob.example.com" or "127.0.0.1:8080/{id}"). Return the host and its id.
+// The id is not related to any request until it's assigned to one (see: Assign)
+func (s *Server) Host() (string, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := newId()
+	for _, exist := s.ids[id]; exist; _, exist = s.ids[id] {
+		id = newId()
+	}
+	s.ids[id] = 0
+
+	if len(s.config.Domain) > 0 {
+		return id + "." + s.config.Domain, id
+	}
+	return s.httpHost() + "/" + id, id
+}
+
+// Assign the ids of the hosts to the request.
+// Note : (The hosts of a request must be made and assigned before the request is sent)
+func (s *Server) Assign(requestId int, ids []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, id := range ids {
+		if _, ok := s.ids[id]; ok {
+			s.ids[id] = requestId
+		}
+	}
+}
+
+// Host of the HTTP server used when no domain is set
+func (s *Server) httpHost() string {
+	host, port, err := net.SplitHostPort(s.HTTPAddress())
+	if err != nil {
+		return s.HTTPAddress()
+	}
+	if len(s.config.IP) > 0 {
+		host = s.config.IP
+	} else if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+	if port == "80" {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// Get the id from a host (Ex: "x.{id}.oob.example.com") or a HTTP path (Ex: "/{id}/x")
+func (s *Server) lookupId(host, path string) (string, bool) {
+	host = strings.Trim(strings.ToLower(host), ".")
+	if d := s.config.Domain; len(d) > 0 && strings.HasSuffix(host, "."+d) {
+		labels := strings.Split(strings.TrimSuffix(host, "."+d), ".")
+		if id := labels[len(labels)-1]; s.known(id) {
+			return id, true
+		}
+	}
+	if id, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/"); s.known(strings.ToLower(id)) {
+		return strings.ToLower(id), true
+	}
+	return "", false
+}
+
+func (s *Server) known(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.ids[id]
+	return ok
+}
+
+// Give the interaction to the channel in case it's the first interaction of its protocol for the id.
+// Note : (the interaction is given once the lock is released, a full channel must not block the other methods)
+func (s *Server) emit(interaction Interaction) {
+	s.mutex.Lock()
+	key := interaction.Id + "|" + interaction.Protocol
+	if s.closed || s.seen[key] {
+		s.mutex.Unlock()
+		return
+	}
+	s.seen[key] = true
+	interaction.RequestId = s.ids[interaction.Id]
+	interaction.Date = time.Now().Format(time.UnixDate)
+	s.emitting.Add(1)
+	s.mutex.Unlock()
+
+	defer s.emitting.Done()
+	s.Interactions <- interaction
+}
+
+func (s *Server) handleHTTP(w http.ResponseWriter, req *http.Request) {
+	host, _, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		host = req.Host
+	}
+	if id, ok := s.lookupId(host, req.URL.Path); ok {
+		raw, _ := httputil.DumpRequest(req, true)
+		s.emit(Interaction{
+			Id:         id,
+			Protocol:   PROTOCOL_HTTP,
+			RemoteAddr: req.RemoteAddr,
+			Query:      req.Method + " " + req.URL.RequestURI(),
+			Raw:        string(raw),
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func newId() string {
+	b := make([]byte, ID_LENGTH)
+	rand.Read(b)
+	for i := range b {
+		b[i] = idChars[int(b[i])%len(idChars)]
+	}
+	return string(b)
+}
@@ -0,0 +1,113 @@
+package parameter
+
+import (
+	"strings"
+)
+
+// Interesting headers (and their default value) that can be added to the "header" position.
+// Note : (Headers that are already given in the request are not added)
+var INTERESTING_HEADERS = [][2]string{
+	{"X-Forwarded-For", "127.0.0.1"},
+	{"X-Forwarded-Host", "localhost"},
+	{"X-Forwarded-Server", "localhost"},
+	{"X-Host", "localhost"},
+	{"X-Real-IP", "127.0.0.1"},
+	{"X-Client-IP", "127.0.0.1"},
+	{"X-Remote-IP", "127.0.0.1"},
+	{"X-Remote-Addr", "127.0.0.1"},
+	{"X-Originating-IP", "127.0.0.1"},
+	{"True-Client-IP", "127.0.0.1"},
+	{"Forwarded", "for=127.0.0.1"},
+	{"X-Original-URL", "/"},
+	{"X-Rewrite-URL", "/"},
+	{"Referer", "http://localhost/"},
+	{"Origin", "http://localhost"},
+}
+
+// Headers that are not fuzzed in the "header" position.
+// Note : (The cookie header has its own position "cookie")
+var SKIP_PARAM_HEADERS = []string{"cookie", "content-length"}
+
+// Set the header parameters from the headers of the request.
+// Each header value becomes a parameter that is fuzzed one at a time. In case "interesting" is true, the headers in "INTERESTING_HEADERS" are added as well.
+// Note : (The parameter name is the header name)
+func (p *Parameter) SetHeaderparams(headers [][2]string, interesting bool) {
+	params := setHeaderParam(headers, interesting)
+	p.Header.Position = "header"
+	p.Header.Params = params
+	for _, param := range params {
+		p.Header.RawQueryInsertPoints = append(p.Header.RawQueryInsertPoints, ruleValue(p.Header, param))
+	}
+}
+
+func (p *Parameter) GetHeaderParam(param string) []paramSettings {
+	return getParam(param, p.Header.Params)
+}
+
+// Make a list of headers for each header parameter where only that header value is adapted based on the given rules.
+// Headers that are not within the given headers (Ex: interesting headers) are added to the end of the list.
+func (p *Parameter) HeaderInsertPoints(headers [][2]string) [][][2]string {
+	var lst [][][2]string
+	for idx, param := range p.Header.Params {
+		var (
+			arr   = append([][2]string{}, headers...)
+			value = p.Header.RawQueryInsertPoints[idx]
+		)
+		if param.Index < len(arr) && strings.EqualFold(arr[param.Index][0], param.Name) {
+			arr[param.Index][1] = value
+		} else {
+			arr = append(arr, [2]string{param.Name, value})
+		}
+		lst = append(lst, arr)
+	}
+	return lst
+}
+
+// Set the param settings of each header value.
+// Note : (The index of a param is the index of the header within the given headers)
+func setHeaderParam(headers [][2]string, interesting bool) []paramSettings {
+	var params []paramSettings
+	add := func(name, value string, index int) {
+		param := paramSettings{
+			Name:      name,
+			Separator: ":",
+			Raw:       value,
+			Value:     strings.TrimSpace(value),
+			Index:     index,
+		}
+		param.setType()
+		params = append(params, param)
+	}
+
+	for idx, h := range headers {
+		if !containHeader(SKIP_PARAM_HEADERS, h[0]) {
+			add(h[0], h[1], idx)
+		}
+	}
+	if interesting {
+		for _, h := range INTERESTING_HEADERS {
+			if !containHeader(headersName(headers), h[0]) {
+				add(h[0], h[1], len(headers))
+			}
+		}
+	}
+	return params
+}
+
+// Check if the header name is within the list (in-case sensitive)
+func containHeader(lst []string, name string) bool {
+	for _, i := range lst {
+		if strings.EqualFold(strings.TrimSpace(i), strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func headersName(headers [][2]string) []string {
+	var lst []string
+	for _, h := range headers {
+		lst = append(lst, h[0])
+	}
+	return lst
+}
@@ -0,0 +1,181 @@
+package parameter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Set the JSON parameters from a raw JSON post body.
+// Each leaf value (string, number, bool and null) in nested objects and arrays becomes a parameter.
+// Note : (The parameter name is the path to the value, Ex: "user.roles[0]")
+func (p *Parameter) SetJSONparams(rawBody string) error {
+	params, err := setJSONParam(rawBody)
+	if err != nil {
+		return err
+	}
+	p.JSON.Position = "json"
+	p.JSON.RawQueryOriginal = rawBody
+	p.JSON.Params = params
+	p.JSON.RawQueryInsertPoint = buildJSONInsertPoint(p.JSON)
+	return nil
+}
+
+func (p *Parameter) GetJSONParam(param string) []paramSettings {
+	return getParam(param, p.JSON.Params)
+}
+
+// Walk the JSON tokens in the order they are given and set the param settings of each leaf value.
+func setJSONParam(rawBody string) ([]paramSettings, error) {
+	var (
+		params  []paramSettings
+		path    []any // Holds the object keys (string) and array indexes (int) to the current value
+		isKey   []bool
+		decoder = json.NewDecoder(strings.NewReader(rawBody))
+	)
+	decoder.UseNumber()
+
+	for {
+		prevOffset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		end := int(decoder.InputOffset())
+
+		// Inside an object every other string token is a key:
+		depth := len(isKey) - 1
+		if depth >= 0 && isKey[depth] {
+			if delim, ok := token.(json.Delim); !ok || delim != '}' {
+				path[depth] = fmt.Sprint(token)
+				isKey[depth] = false
+				continue
+			}
+		}
+
+		switch token := token.(type) {
+		case json.Delim:
+			switch token {
+			case '{':
+				nextIndex(path)
+				path = append(path, "")
+				isKey = append(isKey, true)
+			case '[':
+				nextIndex(path)
+				path = append(path, -1)
+				isKey = append(isKey, false)
+			case '}', ']':
+				path = path[:len(path)-1]
+				isKey = isKey[:len(isKey)-1]
+				nextValue(path, isKey)
+			}
+			continue
+		}
+
+		// The value is placed after the previous token, whitespace and the separators (":" and ","):
+		start := prevOffset + len(rawBody[prevOffset:end]) - len(strings.TrimLeft(rawBody[prevOffset:end], " \t\r\n:,"))
+		nextIndex(path)
+
+		param := paramSettings{
+			Name:   jsonPath(path),
+			Raw:    rawBody[start:end],
+			Value:  token,
+			Index:  len(params),
+			Offset: [2]int{start, end},
+		}
+		param.setJSONType()
+		params = append(params, param)
+
+		nextValue(path, isKey)
+	}
+	if len(params) == 0 {
+		return nil, errors.New("no JSON values found in the body")
+	}
+	return params, nil
+}
+
+// Increase the index of the current array (if the value is placed in an array)
+func nextIndex(path []any) {
+	if depth := len(path) - 1; depth >= 0 {
+		if idx, ok := path[depth].(int); ok {
+			path[depth] = idx + 1
+		}
+	}
+}
+
+// Prepare the path for the next value. In an object, the next token is a key.
+func nextValue(path []any, isKey []bool) {
+	if depth := len(path) - 1; depth >= 0 {
+		if _, ok := path[depth].(string); ok {
+			isKey[depth] = true
+		}
+	}
+}
+
+// Make the path name of a JSON value (Ex: "a.b[0].c")
+func jsonPath(path []any) string {
+	var s string
+	for _, i := range path {
+		switch i := i.(type) {
+		case string:
+			if len(s) > 0 {
+				s += "."
+			}
+			s += i
+		case int:
+			s += "[" + strconv.Itoa(i) + "]"
+		}
+	}
+	return s
+}
+
+// Set the parameter type from the JSON value type.
+// The type of the content in a string value is kept as "TypeValue" (Ex: "123" is a string that holds an integer)
+func (p *paramSettings) setJSONType() {
+	switch v := p.Value.(type) {
+	case string:
+		p.Type = reflect.String
+		s := paramSettings{Value: v}
+		s.setType()
+		p.TypeValue = s.Type
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			p.Type = reflect.Int
+		} else {
+			p.Type = reflect.Float64
+		}
+		p.TypeValue = p.Type
+	case bool:
+		p.Type = reflect.Bool
+		p.TypeValue = p.Type
+	case nil:
+		p.Type = reflect.Invalid
+		p.TypeValue = p.Type
+	}
+}
+
+// Build the raw JSON body adapted to the given insertpoint keyword(s).
+// String values keep their quotes while other values get the keyword as a raw value (Ex: {"name":"FUZZ","id":FUZZ})
+func buildJSONInsertPoint(q query) string {
+	return buildOffsetInsertPoint(q, func(p paramSettings) string {
+		switch {
+		case q.Rule.Method == "replace" && p.Type == reflect.String:
+			return `"` + q.Rule.InsertKeyword + `"`
+
+		case q.Rule.Method == "replace":
+			return q.Rule.InsertKeyword
+
+		case q.Rule.Method == "append" && p.Type == reflect.String:
+			return strings.TrimSuffix(p.Raw, `"`) + q.Rule.InsertKeyword + `"`
+
+		default:
+			return p.Raw + q.Rule.InsertKeyword
+		}
+	})
+}
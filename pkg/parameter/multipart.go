@@ -0,0 +1,142 @@
+package parameter
+
+import (
+	"errors"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+var (
+	re_multipartName        = regexp.MustCompile(`(?i)[;\s]name="([^"]*)"`)
+	re_multipartFilename    = regexp.MustCompile(`(?i)[;\s]filename="([^"]*)"`)
+	re_multipartContentType = regexp.MustCompile(`(?im)^content-type:[ \t]*([^\r\n]*)`)
+)
+
+// Get the boundary from a multipart content type header value (Ex: "multipart/form-data; boundary=xyz")
+func GetMultipartBoundary(contentType string) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") || len(params["boundary"]) == 0 {
+		return "", errors.New("the content type is not multipart or is missing the boundary")
+	}
+	return params["boundary"], nil
+}
+
+// Set the multipart parameters from a raw multipart/form-data post body and its content type header value.
+// The value, filename and content type of each part becomes parameters.
+// Note : (The parameter name is the part name for the value, Ex: "file" and "file.filename", "file.content-type" for the properties of the part)
+func (p *Parameter) SetMultipartparams(rawBody, contentType string) error {
+	boundary, err := GetMultipartBoundary(contentType)
+	if err != nil {
+		return err
+	}
+	params, err := setMultipartParam(rawBody, boundary)
+	if err != nil {
+		return err
+	}
+	p.Multipart.Position = "multipart"
+	p.Multipart.Boundary = boundary
+	p.Multipart.RawQueryOriginal = rawBody
+	p.Multipart.Params = params
+	p.Multipart.RawQueryInsertPoint = buildOffsetInsertPoint(p.Multipart, func(param paramSettings) string {
+		return ruleValue(p.Multipart, param)
+	})
+	return nil
+}
+
+func (p *Parameter) GetMultipartParam(param string) []paramSettings {
+	return getParam(param, p.Multipart.Params)
+}
+
+// Split the raw multipart body by the boundary and set the param settings of each part.
+func setMultipartParam(rawBody, boundary string) ([]paramSettings, error) {
+	var (
+		params    []paramSettings
+		delimiter = "--" + boundary
+		offset    = strings.Index(rawBody, delimiter)
+	)
+	if offset < 0 {
+		return nil, errors.New("the multipart body do not contain the boundary")
+	}
+
+	add := func(name, value string, start, end int) {
+		param := paramSettings{
+			Name:   name,
+			Raw:    value,
+			Value:  value,
+			Index:  len(params),
+			Offset: [2]int{start, end},
+		}
+		param.setType()
+		params = append(params, param)
+	}
+
+	for {
+		// Each part starts after the delimiter line and ends before the next delimiter ("\r\n--{boundary}"):
+		offset += len(delimiter)
+		if strings.HasPrefix(rawBody[offset:], "--") {
+			break
+		}
+		next := strings.Index(rawBody[offset:], delimiter)
+		if next < 0 {
+			return nil, errors.New("the multipart body is missing the closing boundary")
+		}
+		var (
+			partStart = offset + lineEndLength(rawBody[offset:])
+			partEnd   = offset + next
+		)
+		offset = partEnd
+
+		// Trim the line break that belongs to the delimiter:
+		partEnd -= len(rawBody[partStart:partEnd]) - len(strings.TrimSuffix(strings.TrimSuffix(rawBody[partStart:partEnd], "\n"), "\r"))
+
+		headerEnd, bodyStart := splitPart(rawBody[partStart:partEnd])
+		if headerEnd < 0 {
+			continue
+		}
+		headers := rawBody[partStart : partStart+headerEnd]
+
+		m := re_multipartName.FindStringSubmatch(headers)
+		if m == nil {
+			continue
+		}
+		name := m[1]
+		add(name, rawBody[partStart+bodyStart:partEnd], partStart+bodyStart, partEnd)
+
+		if m := re_multipartFilename.FindStringSubmatchIndex(headers); m != nil {
+			add(name+".filename", headers[m[2]:m[3]], partStart+m[2], partStart+m[3])
+		}
+		if m := re_multipartContentType.FindStringSubmatchIndex(headers); m != nil {
+			value := strings.TrimRight(headers[m[2]:m[3]], " \t")
+			add(name+".content-type", value, partStart+m[2], partStart+m[2]+len(value))
+		}
+	}
+	if len(params) == 0 {
+		return nil, errors.New("no parts found in the multipart body")
+	}
+	return params, nil
+}
+
+// Split a part into its headers and body. Return the end of the headers and the start of the body (-1 if the part is invalid)
+func splitPart(part string) (int, int) {
+	for _, sep := range []string{"\r\n\r\n", "\n\n"} {
+		if idx := strings.Index(part, sep); idx >= 0 {
+			return idx, idx + len(sep)
+		}
+	}
+	return -1, -1
+}
+
+// Get the length of the line break at the start of the string ("\r\n" or "\n")
+func lineEndLength(s string) int {
+	switch {
+	case strings.HasPrefix(s, "\r\n"):
+		return 2
+	case strings.HasPrefix(s, "\n"):
+		return 1
+	}
+	return 0
+}
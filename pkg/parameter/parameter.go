@@ -1,9 +1,11 @@
 package parameter
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,20 +14,30 @@ import (
 
 // Global parameter variables:
 var (
-	SUPPORTED_PARAM_POSITIONS = []string{"url", "body", "cookie"}
+	SUPPORTED_PARAM_POSITIONS = []string{"url", "body", "cookie", "json", "xml", "multipart", "header", "path"}
 	SUPPORTED_PARAM_METHODS   = []string{"replace", "append"}
 )
 
 type Parameter struct {
 	// InsertKeyword is a shortcut to access the global insert keyword used for all params in all positions
 	// Keyword presented for example in: URL, Body, Cookie
-	InsertKeyword   string
-	AutoQueryURL    bool
-	AutoQueryBody   bool
-	AutoQueryCookie bool
-	URL             query
-	Body            query
-	Cookie          query
+	InsertKeyword      string
+	AutoQueryURL       bool
+	AutoQueryBody      bool
+	AutoQueryCookie    bool
+	AutoQueryJSON      bool
+	AutoQueryXML       bool
+	AutoQueryMultipart bool
+	AutoQueryHeader    bool
+	AutoQueryPath      bool
+	URL                query
+	Body               query
+	Cookie             query
+	JSON               query
+	XML                query
+	Multipart          query
+	Header             query
+	Path               query
 }
 
 type query struct {
@@ -34,6 +46,9 @@ type query struct {
 	RawQueryOriginal string
 	// Holds the built raw query and adapted raw query based on the given rules
 	RawQueryInsertPoint string
+	// Holds one built raw query for each param where only that param is adapted based on the given rules
+	// Note : (Used by positions that are fuzzed one param at a time, Ex: header, path)
+	RawQueryInsertPoints []string
 	// Holds all the params and it's settings / properties, including it's values
 	Params []paramSettings
 	// Position holds the name of the position the request parameter was placed.
@@ -41,6 +56,8 @@ type query struct {
 	Position string
 	// The rules to be used for the param and it's settings
 	Rule QueryRules
+	// The boundary of a multipart body (only used by the "multipart" position)
+	Boundary string
 }
 
 type QueryRules struct {
@@ -71,12 +88,15 @@ type paramSettings struct {
 	// Param types can be string, int, bool, array, list, object etc...
 	// Note : (By default if no parameter type is set, the default will be "string")
 	Type reflect.Kind
-	// Comment ...
+	// TypeValue representate the type of the content within the value (Ex: a JSON string "123" holds an integer)
 	TypeValue reflect.Kind
+	// The start and end (byte) offset of the value within the raw query.
+	// Note : (Only used by positions that are not split by separators, Ex: json)
+	Offset [2]int
 }
 
 // Make a param object that contain the param settings and rules for each position.
-// The argument "paramRules" holds the supported *position* ("url", "cookie", "body") and the rules for the parameter placed in that position.
+// The argument "paramRules" holds the supported *position* ("url", "cookie", "body", "json", "xml", "multipart", "header", "path") and the rules for the parameter placed in that position.
 func NewParameter(rule map[string]QueryRules, insertKeyword string) (Parameter, error) {
 	parameter := Parameter{}
 
@@ -101,6 +121,26 @@ func NewParameter(rule map[string]QueryRules, insertKeyword string) (Parameter,
 				parameter.Cookie = q
 				parameter.AutoQueryCookie = true
 
+			case "json":
+				parameter.JSON = q
+				parameter.AutoQueryJSON = true
+
+			case "xml":
+				parameter.XML = q
+				parameter.AutoQueryXML = true
+
+			case "multipart":
+				parameter.Multipart = q
+				parameter.AutoQueryMultipart = true
+
+			case "header":
+				parameter.Header = q
+				parameter.AutoQueryHeader = true
+
+			case "path":
+				parameter.Path = q
+				parameter.AutoQueryPath = true
+
 			default:
 				return Parameter{}, errors.New("invalid method used, only support: " + strings.Join(SUPPORTED_PARAM_POSITIONS, ","))
 			}
@@ -283,6 +323,50 @@ func buildRawQueryInsertPoint(q query) string {
 	return rawQuery
 }
 
+// Build a raw query adapted to the given insertpoint keyword(s) by replacing each parameter value at its offset within the original raw query.
+// The function "value" returns the raw value to be placed at the offset of the parameter.
+// Note : (Used by positions that are not split by separators, Ex: json, xml, multipart)
+func buildOffsetInsertPoint(q query, value func(p paramSettings) string) string {
+	var (
+		buf    bytes.Buffer
+		last   = 0
+		params = append([]paramSettings{}, q.Params...)
+	)
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].Offset[0] < params[j].Offset[0]
+	})
+
+	for _, p := range params {
+		buf.WriteString(q.RawQueryOriginal[last:p.Offset[0]])
+		buf.WriteString(value(p))
+		last = p.Offset[1]
+	}
+	buf.WriteString(q.RawQueryOriginal[last:])
+	return buf.String()
+}
+
+// Build one raw query for each parameter where only that parameter is adapted to the given insertpoint keyword(s).
+// Note : (Raw queries that are identical to a previous one are skipped)
+func buildOffsetInsertPoints(q query, value func(p paramSettings) string) []string {
+	var lst []string
+	for _, p := range q.Params {
+		single := q
+		single.Params = []paramSettings{p}
+		if s := buildOffsetInsertPoint(single, value); !slices.Contains(lst, s) {
+			lst = append(lst, s)
+		}
+	}
+	return lst
+}
+
+// Get the raw value adapted to the method (replace/append) of the rule
+func ruleValue(q query, p paramSettings) string {
+	if q.Rule.Method == "append" {
+		return p.Raw + q.Rule.InsertKeyword
+	}
+	return q.Rule.InsertKeyword
+}
+
 // Get the default param separators based on the given param point (placed at)
 func getSeparators(point string) ([]rune, error) {
 	var defaultSeparators = map[string][]rune{
@@ -0,0 +1,62 @@
+package parameter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Set the path parameters from a raw (escaped) URL path.
+// Each "/" separated segment and the file extension of the last segment becomes parameters that are fuzzed one at a time.
+// Note : (The parameter name is the index of the segment, Ex: "path[1]" and "path[1].extension" for the file extension)
+func (p *Parameter) SetPathparams(rawPath string) {
+	params := setPathParam(rawPath)
+	p.Path.Position = "path"
+	p.Path.RawQueryOriginal = rawPath
+	p.Path.Params = params
+	p.Path.RawQueryInsertPoints = buildOffsetInsertPoints(p.Path, func(param paramSettings) string {
+		return ruleValue(p.Path, param)
+	})
+}
+
+func (p *Parameter) GetPathParam(param string) []paramSettings {
+	return getParam(param, p.Path.Params)
+}
+
+// Split the raw path into segments and set the param settings of each segment and the file extension (if any).
+func setPathParam(rawPath string) []paramSettings {
+	var (
+		params  []paramSettings
+		segment = 0
+		start   = 0
+	)
+	add := func(name, value string, start, end int) {
+		param := paramSettings{
+			Name:      name,
+			Separator: "/",
+			Raw:       value,
+			Value:     value,
+			Index:     len(params),
+			Offset:    [2]int{start, end},
+		}
+		param.setType()
+		params = append(params, param)
+	}
+
+	for idx := 0; idx <= len(rawPath); idx++ {
+		if idx < len(rawPath) && rawPath[idx] != '/' {
+			continue
+		}
+		if value := rawPath[start:idx]; len(value) > 0 {
+			name := "path[" + strconv.Itoa(segment) + "]"
+			add(name, value, start, idx)
+
+			// Only the last segment can hold a file extension (Ex: "/api/index.php"):
+			if dot := strings.LastIndex(value, "."); idx == len(rawPath) && dot > 0 && dot < len(value)-1 {
+				add(name+".extension", value[dot+1:], start+dot+1, idx)
+			}
+			segment++
+		}
+		start = idx + 1
+	}
+	return params
+}
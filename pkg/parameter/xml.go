@@ -0,0 +1,176 @@
+package parameter
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var re_xmlAttribute = regexp.MustCompile(`\s([^\s=/>]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+const (
+	xmlCDATAStart = "<![CDATA["
+	xmlCDATAEnd   = "]]>"
+)
+
+// Element that is currently walked within the XML body
+type xmlElement struct {
+	name     string
+	children bool
+	text     []paramSettings
+}
+
+// Set the XML parameters from a raw XML post body (Ex: SOAP).
+// The text of elements that have no child elements and all the attribute values becomes parameters.
+// Note : (The parameter name is the path to the element, Ex: "soap:Envelope/soap:Body/login/user" and "login/user@type" for attributes)
+func (p *Parameter) SetXMLparams(rawBody string) error {
+	params, err := setXMLParam(rawBody)
+	if err != nil {
+		return err
+	}
+	p.XML.Position = "xml"
+	p.XML.RawQueryOriginal = rawBody
+	p.XML.Params = params
+	p.XML.RawQueryInsertPoint = buildOffsetInsertPoint(p.XML, func(param paramSettings) string {
+		return ruleValue(p.XML, param)
+	})
+	return nil
+}
+
+func (p *Parameter) GetXMLParam(param string) []paramSettings {
+	return getParam(param, p.XML.Params)
+}
+
+// Walk the raw XML tokens and set the param settings of each element text and attribute value.
+func setXMLParam(rawBody string) ([]paramSettings, error) {
+	var (
+		params  []paramSettings
+		stack   []xmlElement
+		decoder = xml.NewDecoder(strings.NewReader(rawBody))
+	)
+	decoder.Strict = false
+
+	add := func(name, raw, value string, start, end int) {
+		param := paramSettings{
+			Name:   name,
+			Raw:    raw,
+			Value:  value,
+			Offset: [2]int{start, end},
+		}
+		param.setType()
+		params = append(params, param)
+	}
+
+	for {
+		prevOffset := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		var (
+			end = int(decoder.InputOffset())
+			raw = rawBody[prevOffset:end]
+		)
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if len(stack) > 0 {
+				stack[len(stack)-1].children = true
+			}
+			stack = append(stack, xmlElement{name: xmlName(token.Name)})
+			path := xmlPath(stack)
+
+			// The attribute values are located within the raw start element:
+			for _, m := range re_xmlAttribute.FindAllStringSubmatchIndex(raw, -1) {
+				name := raw[m[2]:m[3]]
+				if name == "xmlns" || strings.HasPrefix(name, "xmlns:") {
+					continue
+				}
+				start, stop := m[4], m[5]
+				if start < 0 {
+					start, stop = m[6], m[7]
+				}
+				add(path+"@"+name, raw[start:stop], xmlAttrValue(token.Attr, name), prevOffset+start, prevOffset+stop)
+			}
+
+		case xml.CharData:
+			if len(stack) == 0 || len(strings.TrimSpace(raw)) == 0 {
+				continue
+			}
+			start, stop := prevOffset, end
+			if trimmed := strings.TrimSpace(raw); strings.HasPrefix(trimmed, xmlCDATAStart) {
+				start = prevOffset + strings.Index(raw, xmlCDATAStart) + len(xmlCDATAStart)
+				stop = prevOffset + strings.LastIndex(raw, xmlCDATAEnd)
+			} else {
+				start += len(raw) - len(strings.TrimLeft(raw, " \t\r\n"))
+				stop -= len(raw) - len(strings.TrimRight(raw, " \t\r\n"))
+			}
+			top := &stack[len(stack)-1]
+			top.text = append(top.text, paramSettings{
+				Raw:    rawBody[start:stop],
+				Value:  string(token),
+				Offset: [2]int{start, stop},
+			})
+
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("unexpected end element in the XML body")
+			}
+			var (
+				top  = stack[len(stack)-1]
+				path = xmlPath(stack)
+			)
+			stack = stack[:len(stack)-1]
+			if top.children {
+				continue
+			}
+
+			for _, text := range top.text {
+				add(path, text.Raw, text.Value.(string), text.Offset[0], text.Offset[1])
+			}
+			// An empty element (Ex: <a></a>) have its insert point between the start and end element.
+			// Note : (A self-closing element (Ex: <a/>) has no raw end element and is skipped)
+			if len(top.text) == 0 && len(raw) > 0 {
+				add(path, "", "", prevOffset, prevOffset)
+			}
+		}
+	}
+	if len(params) == 0 {
+		return nil, errors.New("no XML values found in the body")
+	}
+	for idx := range params {
+		params[idx].Index = idx
+	}
+	return params, nil
+}
+
+// Get the name of an element or attribute including its prefix (Ex: "soap:Body")
+func xmlName(name xml.Name) string {
+	if len(name.Space) > 0 {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// Make the path name of the current element (Ex: "Envelope/Body/login")
+func xmlPath(stack []xmlElement) string {
+	var lst []string
+	for _, e := range stack {
+		lst = append(lst, e.name)
+	}
+	return strings.Join(lst, "/")
+}
+
+// Get the (unescaped) value of an attribute
+func xmlAttrValue(attrs []xml.Attr, name string) string {
+	for _, attr := range attrs {
+		if xmlName(attr.Name) == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
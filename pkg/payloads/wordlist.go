@@ -9,16 +9,21 @@ import (
 	"strings"
 
 	"github.com/Brum3ns/firefly/pkg/encode"
+	"github.com/Brum3ns/firefly/pkg/random"
 )
 
 // Wordlist global tag names:
 var (
 	TAG_VERIFY         = "Verify"
+	TAG_CALIBRATE      = "Calibrate"
 	TAG_FUZZ           = "Fuzz"
 	TAG_TRANSFORMATION = "Transformation"
-	TAGS               = []string{TAG_VERIFY, TAG_FUZZ, TAG_TRANSFORMATION}
+	TAGS               = []string{TAG_VERIFY, TAG_CALIBRATE, TAG_FUZZ, TAG_TRANSFORMATION}
 )
 
+// Amount of random payloads used to calibrate the targets (auto calibration)
+var CALIBRATE_AMOUNT = 4
+
 // Wordlist structure stores the wordlist and tags
 type Wordlist struct {
 	Wordlist           map[string][]string //(tag|wordlist)
@@ -40,6 +45,8 @@ type PayloadProperties struct {
 type Verify struct {
 	Payload string
 	Amount  int
+	// Amount of random calibration payloads (zero if auto calibration is not used)
+	Calibrate int
 }
 
 // Create a new wordlist object
@@ -48,6 +55,7 @@ func NewWordlist(wl *Wordlist) *Wordlist {
 
 	//Create verify wordlist
 	wl.Wordlist[TAG_VERIFY] = verifyWordlist(wl.Verify.Payload, wl.Verify.Amount)
+	wl.Wordlist[TAG_CALIBRATE] = calibrateWordlist(wl.Verify.Calibrate)
 
 	//Create fuzz wordlist by combining all wordlist files given (if multiple)
 	for _, filename := range wl.Files {
@@ -69,6 +77,11 @@ func (wl *Wordlist) Get(tag string) ([]string, error) {
 	}
 }
 
+// Load a wordlist file as payloads adapted to the payload properties (Ex: encode, prefix, suffix)
+func (wl *Wordlist) LoadFile(filePath string) []string {
+	return wl.createPayloadWordlist(filePath)
+}
+
 // Return a map containing all the wordlists and tags (tag as the key)
 func (wl *Wordlist) GetAll() map[string][]string {
 	return wl.Wordlist
@@ -82,6 +95,20 @@ func verifyWordlist(verifyPayload string, amount int) []string {
 	return lst
 }
 
+// Create random garbage payloads of different lengths used to detect the default response of the targets
+func calibrateWordlist(amount int) []string {
+	var lst []string
+	for i := 0; i < amount; i++ {
+		lst = append(lst, random.RandString(12+(i*8)))
+	}
+	return lst
+}
+
+// Check if the tag belongs to the verification process (the verify and calibrate payloads)
+func IsVerifyTag(tag string) bool {
+	return tag == TAG_VERIFY || tag == TAG_CALIBRATE
+}
+
 // Create a wordlist by a given file path
 func (wl Wordlist) createWordlist(filePath string) []string {
 	file, err := os.Open(filePath)
@@ -14,6 +14,9 @@ var Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
+// Keyword replaced with a unique out-of-band host (Ex: "{id}.oob.example.com")
+const OOB_KEYWORD = "#OOB#"
+
 // Insert the a random string/int based on rules: (Ex: s:7, n:3) {
 func RandomInsert(s string) string {
 	var (
@@ -35,6 +38,15 @@ func RandomInsert(s string) string {
 	return s
 }
 
+// Insert a new out-of-band host made by "host" where the keyword "#OOB#" is used (the same host is used for all the keywords within the string).
+// The keyword is kept as it is in case "host" is nil (the OOB server is not used).
+func OOBInsert(s string, host func() string) string {
+	if host == nil || !strings.Contains(s, OOB_KEYWORD) {
+		return s
+	}
+	return strings.ReplaceAll(s, OOB_KEYWORD, host())
+}
+
 // Craft a random str/int value with "x" length - "[t]ype:[l]ength" Return the random value
 func RandomCreate(t string, l int) string {
 	switch t {
@@ -2,13 +2,15 @@ package request
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/Brum3ns/firefly/pkg/waitgroup"
 )
 
 type Handler struct {
-	jobAmount   int
+	jobAmount   *atomic.Int64
+	requestId   *atomic.Int64
 	Worker      worker
 	TaskStorage *TaskStorage
 	WaitGroup   waitgroup.WaitGroup
@@ -52,6 +54,8 @@ type TaskStorage struct {
 func NewHandler(settings HandlerSettings) Handler { // httpclient *http.Client, task *TaskStorage, threads int, delay int, verifyMode bool) *Handler {
 	return Handler{
 		HandlerSettings: settings,
+		jobAmount:       new(atomic.Int64),
+		requestId:       new(atomic.Int64),
 		stop:            make(chan bool),
 		JobReceived:     make(chan int),
 		JobQueue:        make(chan RequestSettings),
@@ -95,14 +99,21 @@ func (h *Handler) Run(listener chan<- Result) {
 	<-h.stop
 }
 
-// Add a job process to the handler
+// Add a job process to the handler. The job is given a new request id unless it already has one (see: NewRequestId)
 func (h *Handler) AddJob(job RequestSettings) {
 	h.WaitGroup.Add(1)
-	h.jobAmount++
-	job.RequestId = h.jobAmount
+	h.jobAmount.Add(1)
+	if job.RequestId == 0 {
+		job.RequestId = h.NewRequestId()
+	}
 	h.JobQueue <- job
 }
 
+// Make a new unique request id. It can be given to a job before it's added (Ex: to relate other data to the request before it's sent)
+func (h *Handler) NewRequestId() int {
+	return int(h.requestId.Add(1))
+}
+
 // Get the amount of job that are active
 func (e *Handler) GetJobInProcess() int {
 	return e.WaitGroup.GetCount()
@@ -125,7 +136,7 @@ func (h *Handler) GetInProcess() int {
 
 // Get the amount of given jobs
 func (h *Handler) GetJobAmount() int {
-	return h.jobAmount
+	return int(h.jobAmount.Load())
 }
 
 // Create a new request worker
@@ -28,6 +28,7 @@ type Result struct {
 	Tag          string
 	Date         string
 	Payload      string
+	InsertPoints map[string]string
 	Request      HttpRequest
 	Response     Response
 	Skip         bool
@@ -51,9 +52,10 @@ type Response struct {
 
 // HttpRequest configuration (alias of the "http.HttpRequest" struct but with some extra variables added)
 type HttpRequest struct {
-	Body            string
-	URLOriginal     string
-	HeadersOriginal [][2]string
+	Body             string
+	URLOriginal      string
+	PostBodyOriginal string
+	HeadersOriginal  [][2]string
 	http.Request
 }
 
@@ -67,6 +69,8 @@ type RequestSettings struct {
 	Payload      string
 	Method       string
 	UserAgents   []string
+	// Named insert points and the payload inserted within them (keyword|payload)
+	InsertPoints map[string]string
 	Parameter    parameter.Parameter
 	RequestBase
 }
@@ -77,7 +81,9 @@ type RequestBase struct {
 	InsertPoint          string
 	RandomUserAgent      bool
 	HeadersOriginalArray [][2]string
-	Headers              http.Header
+	// The post body before the payload was inserted
+	PostBodyOriginal string
+	Headers          http.Header
 }
 
 type ClientSettings struct {
@@ -93,6 +99,11 @@ type Host struct {
 	URL    string
 	Scheme string
 	Method string
+	// Target specific request properties (Ex: given by a scope file).
+	// Note : (If a property is not set, the global option will be used instead)
+	Headers  [][2]string
+	PostData string
+	Wordlist string
 }
 
 var (
@@ -109,6 +120,11 @@ func Request(client *http.Client, requestSettings RequestSettings) Result {
 	//Add headers:
 	httpRequest.Header = requestSettings.Headers
 
+	// The host header is ignored by the HTTP client unless it's set as the request host:
+	if host := httpRequest.Header.Get("Host"); len(host) > 0 {
+		httpRequest.Host = host
+	}
+
 	//Add random headers (if set):
 	ruaLength := len(requestSettings.UserAgents)
 	if ruaLength > 0 && requestSettings.RandomUserAgent {
@@ -126,9 +142,6 @@ func Request(client *http.Client, requestSettings RequestSettings) Result {
 		responseTime = float64(time.Since(Timer).Seconds())
 	}
 
-	buffer := new(bytes.Buffer)
-	buffer.ReadFrom(httpRequest.Body)
-
 	//Read the response body content:
 	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -145,12 +158,15 @@ func Request(client *http.Client, requestSettings RequestSettings) Result {
 		RequestId:    requestSettings.RequestId,
 		Tag:          requestSettings.Tag,
 		Payload:      requestSettings.Payload,
+		InsertPoints: requestSettings.InsertPoints,
 		Date:         time.Now().Format(time.UnixDate),
 		Error:        nil,
 		Request: HttpRequest{
-			URLOriginal:     requestSettings.URLOriginal,
-			HeadersOriginal: requestSettings.HeadersOriginalArray,
-			Request:         *httpRequest,
+			Body:             requestSettings.PostBody,
+			URLOriginal:      requestSettings.URLOriginal,
+			PostBodyOriginal: requestSettings.PostBodyOriginal,
+			HeadersOriginal:  requestSettings.HeadersOriginalArray,
+			Request:          *httpRequest,
 		},
 		Response: Response{
 			IPAddress:        GetIPAddresses(response.Request.URL.Hostname()),
@@ -0,0 +1,60 @@
+package scope
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"os"
+)
+
+// Burp Suite "save items" XML structure (only the request properties that are needed)
+type burpItems struct {
+	Items []burpItem `xml:"item"`
+}
+
+type burpItem struct {
+	URL      string `xml:"url"`
+	Protocol string `xml:"protocol"`
+	Method   string `xml:"method"`
+	Request  struct {
+		Base64 bool   `xml:"base64,attr"`
+		Data   string `xml:",chardata"`
+	} `xml:"request"`
+}
+
+// Read a Burp Suite "save items" XML export and return each request as a target.
+func ReadBurp(file string) ([]Target, error) {
+	var (
+		targets []Target
+		items   burpItems
+	)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	for idx, item := range items.Items {
+		raw := item.Request.Data
+		if item.Request.Base64 {
+			b, err := base64.StdEncoding.DecodeString(raw)
+			if err != nil {
+				return nil, fmt.Errorf("burp item %d: %s", idx+1, err)
+			}
+			raw = string(b)
+		}
+
+		// The request line only holds the path, the full URL is taken from the item URL:
+		t, err := ParseRaw(raw, "", item.Protocol)
+		if err != nil {
+			return nil, fmt.Errorf("burp item %d: %s", idx+1, err)
+		}
+		if len(item.URL) > 0 {
+			t.URL = item.URL
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
@@ -0,0 +1,94 @@
+package scope
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// HAR 1.2 structure (only the request properties that are needed)
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request harRequest `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harRequest struct {
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Headers  []harNameValue `json:"headers"`
+	Cookies  []harNameValue `json:"cookies"`
+	PostData *harPostData   `json:"postData"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string         `json:"mimeType"`
+	Text     string         `json:"text"`
+	Params   []harNameValue `json:"params"`
+}
+
+// Read a HAR (1.2) file and return each captured request as a target.
+func ReadHAR(file string) ([]Target, error) {
+	var (
+		targets []Target
+		h       har
+	)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range h.Log.Entries {
+		req := entry.Request
+		if len(req.URL) == 0 {
+			continue
+		}
+		t := Target{
+			URL:    req.URL,
+			Method: strings.ToUpper(req.Method),
+		}
+		for _, header := range req.Headers {
+			name := strings.ToLower(header.Name)
+
+			// Skip HTTP/2 pseudo headers (Ex: ":authority") and headers set by the HTTP client:
+			if _, skip := SKIP_IMPORT_HEADERS[name]; skip || strings.HasPrefix(name, ":") {
+				continue
+			}
+			t.AddHeader(name, header.Value)
+		}
+
+		// The cookies are normally included in the cookie header. Only use the cookie list if the header is missing:
+		if name, _ := getHeader(t.Headers, "cookie"); name == "" && len(req.Cookies) > 0 {
+			t.Cookies = make(map[string]string)
+			for _, c := range req.Cookies {
+				t.Cookies[c.Name] = c.Value
+			}
+		}
+
+		if req.PostData != nil {
+			t.Body = req.PostData.Text
+			if len(t.Body) == 0 && len(req.PostData.Params) > 0 {
+				var lst []string
+				for _, p := range req.PostData.Params {
+					lst = append(lst, p.Name+"="+p.Value)
+				}
+				t.Body = strings.Join(lst, "&")
+			}
+			if name, _ := getHeader(t.Headers, "content-type"); name == "" && len(req.PostData.MimeType) > 0 {
+				t.AddHeader("content-type", req.PostData.MimeType)
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
@@ -0,0 +1,420 @@
+package scope
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+)
+
+// HTTP methods that can be defined as an operation in a path item
+var OPENAPI_METHODS = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Max depth of nested references when resolving a reference or generating a request body from a schema.
+const openAPIMaxDepth = 8
+
+var re_openAPIPathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// OpenAPI holds a decoded OpenAPI 3 or Swagger 2 document
+type openAPI struct {
+	doc           map[string]any
+	swagger       bool
+	insertKeyword string
+}
+
+// An operation parameter (Note : "in" can be: path, query, header, cookie, body (Swagger 2) and formData (Swagger 2))
+type openAPIParam struct {
+	name   string
+	in     string
+	schema any
+}
+
+// Read an OpenAPI 3 or Swagger 2 document (JSON or YAML) and return a target for each operation.
+// All the parameters (path, query, header, cookie and form values) are given the insert keyword as value.
+// JSON bodies are generated with example values from the schema and their values are auto detected as parameters (json:replace).
+// The server argument (optional) replaces the server(s) defined in the document.
+func ReadOpenAPI(file, insertKeyword, server string) ([]Target, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	default:
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	api := openAPI{insertKeyword: insertKeyword}
+	if m, ok := normalizeYAML(doc).(map[string]any); ok {
+		api.doc = m
+	} else {
+		return nil, errors.New("invalid OpenAPI document")
+	}
+	if _, ok := api.doc["swagger"]; ok {
+		api.swagger = true
+	} else if _, ok := api.doc["openapi"]; !ok {
+		return nil, errors.New("the document is missing the \"openapi\" or \"swagger\" version field")
+	}
+
+	if len(server) == 0 {
+		server = api.server()
+	}
+	if len(server) == 0 {
+		return nil, errors.New("no absolute server URL was found in the document, set one with \"-openapi-server\"")
+	}
+	return api.targets(strings.TrimSuffix(server, "/"))
+}
+
+// Get the base URL of the API from the document.
+// Note : (Relative server URLs can't be used since the document location is unknown)
+func (api openAPI) server() string {
+	if api.swagger {
+		host := getString(api.doc, "host")
+		if len(host) == 0 {
+			return ""
+		}
+		scheme := "https"
+		if schemes, ok := api.doc["schemes"].([]any); ok && len(schemes) > 0 {
+			scheme = fmt.Sprint(schemes[0])
+		}
+		return scheme + "://" + host + getString(api.doc, "basePath")
+	}
+
+	servers, _ := api.doc["servers"].([]any)
+	for _, s := range servers {
+		server, _ := s.(map[string]any)
+		u := getString(server, "url")
+
+		// Replace server variables with their default value:
+		variables, _ := server["variables"].(map[string]any)
+		for name, v := range variables {
+			variable, _ := v.(map[string]any)
+			u = strings.ReplaceAll(u, "{"+name+"}", getString(variable, "default"))
+		}
+		if parsed, err := url.Parse(u); err == nil && parsed.IsAbs() {
+			return u
+		}
+	}
+	return ""
+}
+
+// Make a target for each operation in the document.
+// Note : (Paths are sorted to always produce the same order of targets)
+func (api openAPI) targets(server string) ([]Target, error) {
+	var targets []Target
+
+	paths, _ := api.doc["paths"].(map[string]any)
+	for _, path := range sortedKeys(paths) {
+		item, _ := api.resolve(paths[path]).(map[string]any)
+		common := api.parameters(item["parameters"])
+
+		for _, method := range OPENAPI_METHODS {
+			operation, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			t, err := api.target(server, path, method, operation, common)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %s", strings.ToUpper(method), path, err)
+			}
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no operations were found in the document")
+	}
+	return targets, nil
+}
+
+// Make a target from an operation. Parameters defined in the path item are used unless the operation overrides them (same name and location).
+func (api openAPI) target(server, path, method string, operation map[string]any, common []openAPIParam) (Target, error) {
+	var (
+		t      = Target{Method: strings.ToUpper(method)}
+		query  []string
+		form   []string
+		params = api.parameters(operation["parameters"])
+	)
+	for _, p := range common {
+		if !containParam(params, p) {
+			params = append(params, p)
+		}
+	}
+
+	for _, p := range params {
+		switch p.in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.name+"}", api.insertKeyword)
+		case "query":
+			query = append(query, url.QueryEscape(p.name)+"="+api.insertKeyword)
+		case "header":
+			t.AddHeader(p.name, api.insertKeyword)
+		case "cookie":
+			if t.Cookies == nil {
+				t.Cookies = make(map[string]string)
+			}
+			t.Cookies[p.name] = api.insertKeyword
+		case "formData":
+			form = append(form, url.QueryEscape(p.name)+"="+api.insertKeyword)
+		case "body":
+			body, err := api.jsonBody(p.schema)
+			if err != nil {
+				return t, err
+			}
+			t.Body = body
+			t.AutoParams = "json:replace"
+			t.AddHeader("content-type", "application/json")
+		}
+	}
+
+	// Path parameters that are not declared are still insert points:
+	path = re_openAPIPathParam.ReplaceAllString(path, api.insertKeyword)
+
+	t.URL = server + path
+	if len(query) > 0 {
+		t.URL += "?" + strings.Join(query, "&")
+	}
+	if len(form) > 0 {
+		t.Body = strings.Join(form, "&")
+		t.AddHeader("content-type", "application/x-www-form-urlencoded")
+	}
+
+	if requestBody, ok := api.resolve(operation["requestBody"]).(map[string]any); ok {
+		if err := api.setRequestBody(&t, requestBody); err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+// Set the post body (OpenAPI 3) from the request body content. JSON is preferred over form data.
+// Note : (Other content types are not supported and the request will be sent without a body)
+func (api openAPI) setRequestBody(t *Target, requestBody map[string]any) error {
+	content, _ := requestBody["content"].(map[string]any)
+
+	for _, contentType := range sortedKeys(content) {
+		if !isJSONContentType(contentType) {
+			continue
+		}
+		media, _ := content[contentType].(map[string]any)
+		body, err := api.jsonBody(media["schema"])
+		if err != nil {
+			return err
+		}
+		t.Body = body
+		t.AutoParams = "json:replace"
+		t.AddHeader("content-type", contentType)
+		return nil
+	}
+
+	if media, ok := content["application/x-www-form-urlencoded"].(map[string]any); ok {
+		var (
+			form      []string
+			schema, _ = api.resolve(media["schema"]).(map[string]any)
+		)
+		properties, _ := schema["properties"].(map[string]any)
+		for _, name := range sortedKeys(properties) {
+			form = append(form, url.QueryEscape(name)+"="+api.insertKeyword)
+		}
+		t.Body = strings.Join(form, "&")
+		t.AddHeader("content-type", "application/x-www-form-urlencoded")
+	}
+	return nil
+}
+
+// Get the parameters from a list of (referenced) parameter objects
+func (api openAPI) parameters(v any) []openAPIParam {
+	var params []openAPIParam
+
+	lst, _ := v.([]any)
+	for _, i := range lst {
+		m, ok := api.resolve(i).(map[string]any)
+		if !ok {
+			continue
+		}
+		p := openAPIParam{
+			name: getString(m, "name"),
+			in:   getString(m, "in"),
+		}
+		p.schema = m["schema"]
+		if len(p.name) > 0 && len(p.in) > 0 {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// Make a JSON body with example values from a schema
+func (api openAPI) jsonBody(schema any) (string, error) {
+	body, err := json.Marshal(api.example(schema, nil))
+	return string(body), err
+}
+
+// Generate an example value from a (referenced) schema.
+// The value given by "example", "default" or "enum" is used (in that order). Otherwise a value is made based on the type.
+// The argument "refs" holds the references that are already being generated and a recursive reference returns nil.
+func (api openAPI) example(v any, refs []string) any {
+	if m, ok := v.(map[string]any); ok {
+		if ref, ok := m["$ref"].(string); ok {
+			if slices.Contains(refs, ref) || len(refs) > openAPIMaxDepth {
+				return nil
+			}
+			refs = append(refs, ref)
+		}
+	}
+	schema, _ := api.resolve(v).(map[string]any)
+
+	for _, key := range []string{"example", "default"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	// Combined schemas. "allOf" is merged and for "oneOf"/"anyOf" the first schema is used:
+	if all, ok := schema["allOf"].([]any); ok {
+		merged := map[string]any{}
+		for _, s := range all {
+			if m, ok := api.example(s, refs).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if lst, ok := schema[key].([]any); ok && len(lst) > 0 {
+			return api.example(lst[0], refs)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		obj := map[string]any{}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, property := range properties {
+			if value := api.example(property, refs); value != nil {
+				obj[name] = value
+			}
+		}
+		return obj
+
+	case "array":
+		if item := api.example(schema["items"], refs); item != nil {
+			return []any{item}
+		}
+		return []any{}
+
+	case "integer":
+		return 1
+
+	case "number":
+		return 1.5
+
+	case "boolean":
+		return true
+
+	default:
+		return "string"
+	}
+}
+
+// Resolve a local reference (Ex: "#/components/schemas/User"). If the value is not a reference it is returned as it is.
+// Note : (External references are not supported)
+func (api openAPI) resolve(v any) any {
+	for i := 0; i < openAPIMaxDepth; i++ {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return v
+		}
+
+		var node any = api.doc
+		for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+			obj, _ := node.(map[string]any)
+			node = obj[key]
+		}
+		v = node
+	}
+	return v
+}
+
+// Get the type of a schema. If no type is set it is guessed from the schema properties.
+func schemaType(schema map[string]any) string {
+	if typ := getString(schema, "type"); len(typ) > 0 {
+		return typ
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return "string"
+}
+
+// Check if the content type is JSON (Ex: "application/json", "application/vnd.api+json")
+func isJSONContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "/json") || strings.HasSuffix(contentType, "+json")
+}
+
+// Check if a parameter with the same name and location is in the list
+func containParam(params []openAPIParam, p openAPIParam) bool {
+	for _, i := range params {
+		if i.name == p.name && i.in == p.in {
+			return true
+		}
+	}
+	return false
+}
+
+// Get a string value from a map (empty if not set)
+func getString(m map[string]any, key string) string {
+	if v, ok := m[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// Convert the YAML decoded maps (map[any]any) to JSON compatible maps (map[string]any)
+func normalizeYAML(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return m
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeYAML(value)
+		}
+		return v
+	case []any:
+		for idx, value := range v {
+			v[idx] = normalizeYAML(value)
+		}
+		return v
+	}
+	return v
+}
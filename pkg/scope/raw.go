@@ -0,0 +1,134 @@
+package scope
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Headers that are not kept from imported requests since they are set by the HTTP client for each request.
+var SKIP_IMPORT_HEADERS = map[string]struct{}{
+	"host":              {},
+	"content-length":    {},
+	"connection":        {},
+	"transfer-encoding": {},
+}
+
+// Parse a raw HTTP request and return it as a target.
+// The base URL (scheme and host) is used to create the target URL. If the base URL is empty, the host header will be used together with the given scheme.
+func ParseRaw(raw, baseURL, scheme string) (Target, error) {
+	var (
+		t         = Target{}
+		host      string
+		headerEnd int
+	)
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+
+	// Split the raw request into the request line and headers (top) and the post body (bottom):
+	if headerEnd = strings.Index(raw, "\n\n"); headerEnd >= 0 {
+		t.Body = raw[headerEnd+2:]
+		raw = raw[:headerEnd]
+	}
+	lines := strings.Split(strings.TrimLeft(raw, "\n"), "\n")
+
+	requestLine := strings.Fields(lines[0])
+	if len(requestLine) < 2 {
+		return t, errors.New("invalid request line in the raw HTTP request")
+	}
+	t.Method = strings.ToUpper(requestLine[0])
+	endpoint := requestLine[1]
+
+	for _, line := range lines[1:] {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		h := strings.SplitN(line, ":", 2)
+		if len(h) != 2 {
+			return t, fmt.Errorf("invalid header in the raw HTTP request: %s", line)
+		}
+		name := strings.ToLower(strings.TrimSpace(h[0]))
+		if name == "host" {
+			host = strings.TrimSpace(h[1])
+		}
+		if _, skip := SKIP_IMPORT_HEADERS[name]; skip {
+			continue
+		}
+		t.AddHeader(name, h[1])
+	}
+
+	// The endpoint can already be a full URL (Ex: requests sent to a proxy)
+	if u, err := url.Parse(endpoint); err == nil && u.IsAbs() {
+		t.URL = endpoint
+		return t, nil
+	}
+
+	switch {
+	case len(baseURL) > 0:
+		t.URL = strings.TrimSuffix(baseURL, "/") + endpoint
+	case len(host) > 0:
+		t.URL = scheme + "://" + host + endpoint
+	default:
+		return t, errors.New("can't make a target URL since no host was found in the raw HTTP request")
+	}
+	return t, nil
+}
+
+// Make the auto parameter rules (same format as the "-au" option) for all the supported positions that contain parameters within the target.
+// Note : (This makes it possible for imported requests to have their insertion points auto-marked)
+func AutoParams(t Target, method string) string {
+	var lst []string
+	if u, err := url.Parse(t.URL); err == nil && len(u.RawQuery) > 0 {
+		lst = append(lst, "url:"+method)
+	}
+	if len(t.Body) > 0 && isFormBody(t) {
+		lst = append(lst, "body:"+method)
+	} else if len(t.Body) > 0 && isJSONBody(t) {
+		lst = append(lst, "json:"+method)
+	} else if len(t.Body) > 0 && isXMLBody(t) {
+		lst = append(lst, "xml:"+method)
+	} else if _, contentType := getHeader(t.Headers, "content-type"); len(t.Body) > 0 && strings.HasPrefix(strings.ToLower(contentType), "multipart/form-data") {
+		lst = append(lst, "multipart:"+method)
+	}
+	if name, _ := getHeader(t.Headers, "cookie"); name != "" || len(t.Cookies) > 0 {
+		lst = append(lst, "cookie:"+method)
+	}
+	return strings.Join(lst, ",")
+}
+
+// Check if the post body is form data (Ex: "a=1&b=2")
+func isFormBody(t Target) bool {
+	if _, contentType := getHeader(t.Headers, "content-type"); len(contentType) > 0 {
+		return strings.Contains(strings.ToLower(contentType), "application/x-www-form-urlencoded")
+	}
+	body := strings.TrimSpace(t.Body)
+	return strings.Contains(body, "=") && !strings.HasPrefix(body, "{") && !strings.HasPrefix(body, "[") && !strings.HasPrefix(body, "<")
+}
+
+// Check if the post body is JSON
+func isJSONBody(t Target) bool {
+	if _, contentType := getHeader(t.Headers, "content-type"); len(contentType) > 0 && !isJSONContentType(contentType) {
+		return false
+	}
+	return json.Valid([]byte(t.Body))
+}
+
+// Check if the post body is XML (Ex: SOAP)
+func isXMLBody(t Target) bool {
+	if _, contentType := getHeader(t.Headers, "content-type"); len(contentType) > 0 {
+		return strings.Contains(strings.ToLower(contentType), "xml")
+	}
+	return strings.HasPrefix(strings.TrimSpace(t.Body), "<")
+}
+
+// Get a header and it's value from a header array list (in-case sensitive)
+func getHeader(headers [][2]string, name string) (string, string) {
+	name = strings.ToLower(name)
+	for _, h := range headers {
+		if strings.ToLower(h[0]) == name {
+			return h[0], h[1]
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,167 @@
+// Scope files describe a list of targets where each target can have its own request properties.
+package scope
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Target holds the properties of a single target given in a scope file.
+// Empty values are not overriding the global options and the global value will be used instead.
+type Target struct {
+	URL    string `json:"url" yaml:"url"`
+	Method string `json:"method" yaml:"method"`
+	// Headers holds the headers in the order they were given.
+	// Note : (Header names are transformed to lowercase, the same as the "-H" option)
+	Headers [][2]string       `json:"-" yaml:"-"`
+	Body    string            `json:"body" yaml:"body"`
+	Cookies map[string]string `json:"cookies" yaml:"cookies"`
+	// AutoParams use the same format as the "-au" option (Ex: "url:replace,body:append")
+	AutoParams string `json:"auto-params" yaml:"auto-params"`
+	// Wordlist is a path to a wordlist that will be used for the target instead of the global wordlist(s)
+	Wordlist string `json:"wordlist" yaml:"wordlist"`
+}
+
+// Raw structure used when decoding a target. Headers can either be a map or a list of "name: value"
+type rawTarget struct {
+	Target  `yaml:",inline"`
+	Headers any `json:"headers" yaml:"headers"`
+}
+
+// Read a scope file and return all the targets within it.
+// Supported formats are JSON Lines (".jsonl", ".json") and YAML (".yml", ".yaml").
+func ReadFile(file string) ([]Target, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yml", ".yaml":
+		return parseYAML(data)
+	case ".jsonl", ".json", ".ndjson":
+		return parseJSONL(data)
+	default:
+		return nil, fmt.Errorf("unsupported scope file format \"%s\", supported: .jsonl, .json, .yml, .yaml", filepath.Ext(file))
+	}
+}
+
+// Parse a JSON Lines scope where each line is a target object. Empty lines and lines starting with "#" are ignored.
+func parseJSONL(data []byte) ([]Target, error) {
+	var (
+		targets []Target
+		line    = 0
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line++
+		item := strings.TrimSpace(scanner.Text())
+		if len(item) == 0 || strings.HasPrefix(item, "#") {
+			continue
+		}
+		raw := rawTarget{}
+		if err := json.Unmarshal([]byte(item), &raw); err != nil {
+			return nil, fmt.Errorf("scope line %d: %s", line, err)
+		}
+		t, err := raw.target()
+		if err != nil {
+			return nil, fmt.Errorf("scope line %d: %s", line, err)
+		}
+		targets = append(targets, t)
+	}
+	return targets, scanner.Err()
+}
+
+// Parse a YAML scope that holds a list of target objects
+func parseYAML(data []byte) ([]Target, error) {
+	var (
+		targets []Target
+		raws    []rawTarget
+	)
+	if err := yaml.Unmarshal(data, &raws); err != nil {
+		return nil, err
+	}
+	for idx, raw := range raws {
+		t, err := raw.target()
+		if err != nil {
+			return nil, fmt.Errorf("scope item %d: %s", idx+1, err)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// Convert the raw decoded target to a target and validate it
+func (raw rawTarget) target() (Target, error) {
+	t := raw.Target
+	t.Method = strings.ToUpper(strings.TrimSpace(t.Method))
+	t.URL = strings.TrimSpace(t.URL)
+
+	if len(t.URL) == 0 {
+		return t, errors.New("the target is missing an url")
+	}
+
+	switch headers := raw.Headers.(type) {
+	case nil:
+	case map[string]any:
+		for _, name := range sortedKeys(headers) {
+			t.AddHeader(name, fmt.Sprint(headers[name]))
+		}
+	case map[any]any:
+		m := make(map[string]any)
+		for name, value := range headers {
+			m[fmt.Sprint(name)] = value
+		}
+		for _, name := range sortedKeys(m) {
+			t.AddHeader(name, fmt.Sprint(m[name]))
+		}
+	case []any:
+		for _, h := range headers {
+			if l := strings.SplitN(fmt.Sprint(h), ":", 2); len(l) == 2 {
+				t.AddHeader(l[0], l[1])
+			} else {
+				return t, fmt.Errorf("invalid header: %v", h)
+			}
+		}
+	default:
+		return t, errors.New("headers must be a map or a list of \"name: value\"")
+	}
+	return t, nil
+}
+
+// Add a header to the target
+func (t *Target) AddHeader(name, value string) {
+	t.Headers = append(t.Headers, [2]string{strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)})
+}
+
+// Return the raw cookie header value that combines the cookies given to the target.
+// Note : (Cookies are sorted by name to always produce the same cookie header)
+func (t Target) CookieHeader() string {
+	var lst []string
+	for name, value := range t.Cookies {
+		lst = append(lst, name+"="+value)
+	}
+	sort.Strings(lst)
+	return strings.Join(lst, "; ")
+}
+
+// Return the keys of a map in sorted order
+func sortedKeys(m map[string]any) []string {
+	var lst []string
+	for k := range m {
+		lst = append(lst, k)
+	}
+	sort.Strings(lst)
+	return lst
+}
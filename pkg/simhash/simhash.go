@@ -0,0 +1,73 @@
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+
+	"github.com/Brum3ns/firefly/pkg/httpprepare"
+)
+
+// Make a 64 bit simhash from the features and their weight (feature|weight).
+// Similar feature sets give hashes with a small hamming distance.
+func Hash(features map[string]int) uint64 {
+	var vector [64]int
+	for feature, weight := range features {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				vector[i] += weight
+			} else {
+				vector[i] -= weight
+			}
+		}
+	}
+
+	var hash uint64
+	for i := 0; i < 64; i++ {
+		if vector[i] > 0 {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// Make a simhash of the tokenized HTML body (tags, attributes, words and comments)
+func FromHTMLNode(node httpprepare.HTMLNode) uint64 {
+	features := make(map[string]int)
+	for prefix, m := range map[string]map[string]int{
+		"tag-start:":  node.TagStart,
+		"tag-end:":    node.TagEnd,
+		"tag-self:":   node.TagSelfClose,
+		"word:":       node.Words,
+		"comment:":    node.Comment,
+		"attribute:":  node.Attribute,
+		"attr-value:": node.AttributeValue,
+	} {
+		for k, v := range m {
+			features[prefix+k] += v
+		}
+	}
+	return Hash(features)
+}
+
+// Get the similarity between two hashes from 0 (different) to 1 (identical)
+func Similarity(a, b uint64) float64 {
+	return 1 - float64(bits.OnesCount64(a^b))/64
+}
+
+// Get the highest similarity between the hash and the baseline hashes.
+// Note : (In case there is no baseline, the similarity is 1)
+func MaxSimilarity(hash uint64, baseline []uint64) float64 {
+	if len(baseline) == 0 {
+		return 1
+	}
+	var max float64
+	for _, b := range baseline {
+		if s := Similarity(hash, b); s > max {
+			max = s
+		}
+	}
+	return max
+}
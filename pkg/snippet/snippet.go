@@ -0,0 +1,65 @@
+package snippet
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// Default amount of characters (bytes) of context taken on each side of a match
+	WINDOW = 80
+	// Maximum amount of snippets kept for each match item (Ex: a pattern found many times)
+	LIMIT = 3
+)
+
+// A snippet is a match within a source (body or headers) with the text around it
+type Snippet struct {
+	Source string `json:"Source"`
+	// Byte offsets of the match within the source
+	Start  int    `json:"Start"`
+	End    int    `json:"End"`
+	Before string `json:"Before"`
+	Match  string `json:"Match"`
+	After  string `json:"After"`
+}
+
+// Make a snippet of the match (s[start:end]) with a window of context on each side.
+// Note : (The window is adjusted so that no UTF-8 character is split)
+func New(source, s string, start, end, window int) Snippet {
+	from, to := max(start-window, 0), min(end+window, len(s))
+	for from > 0 && !utf8.RuneStart(s[from]) {
+		from--
+	}
+	for to < len(s) && !utf8.RuneStart(s[to]) {
+		to++
+	}
+	return Snippet{
+		Source: source,
+		Start:  start,
+		End:    end,
+		Before: s[from:start],
+		Match:  s[start:end],
+		After:  s[end:to],
+	}
+}
+
+// Make the snippets of the match indexes (as given by "regexp.FindAllStringIndex"), at most "LIMIT" snippets are made
+func FromIndexes(source, s string, indexes [][]int, window int) []Snippet {
+	var lst []Snippet
+	for _, idx := range indexes {
+		if len(lst) >= LIMIT {
+			break
+		}
+		lst = append(lst, New(source, s, idx[0], idx[1], window))
+	}
+	return lst
+}
+
+// Get the snippet in one line where the match is highlighted (Ex: `...error in your »SQL syntax« near...`)
+func (s Snippet) String() string {
+	return oneLine(s.Before) + "»" + oneLine(s.Match) + "«" + oneLine(s.After)
+}
+
+func oneLine(s string) string {
+	return strings.NewReplacer("\r", `\r`, "\n", `\n`, "\t", `\t`).Replace(s)
+}
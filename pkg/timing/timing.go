@@ -0,0 +1,128 @@
+package timing
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Amount of standard deviations above the baseline mean for a response time to be seen as an anomaly
+	DEVIATION_FACTOR = 4.0
+	// Tolerance of the linear relationship between the delays and the response times (Ex: 0.3 = 30%)
+	LINEAR_TOLERANCE = 0.3
+
+	// Delays within time based payloads (Ex: "sleep(5)", "pg_sleep(5)", "WAITFOR DELAY '0:0:5'", "sleep 5", "ping -c 5")
+	regexDelay = regexp.MustCompile(`(?i)((?:pg_)?sleep\s*\(\s*|sleep\s+|benchmark\s*\(\s*|waitfor\s+delay\s+'|timeout\s+(?:/t\s+)?|ping\s+-[cn]\s+)(\d+:\d+:\d+|\d+)`)
+)
+
+// Response time baseline of a target
+type Baseline struct {
+	Mean   float64
+	StdDev float64
+	// Minimum extra time (seconds) above the mean for a response time to be an anomaly
+	Threshold float64
+}
+
+// A probe is a payload that was re-sent to confirm the timing anomaly
+type Probe struct {
+	Payload string  `json:"Payload"`
+	Delay   float64 `json:"Delay"`
+	Time    float64 `json:"Time"`
+}
+
+// Result of the timing analysis
+type Result struct {
+	// The timing anomaly was confirmed
+	OK bool
+	// The result was analyzed (the suspicious payload was re-sent)
+	Checked  bool
+	Baseline float64
+	Time     float64
+	// The response times are linear to the delays within the payload
+	Linear bool
+	Probes []Probe `json:",omitempty"`
+}
+
+// Make the baseline from the response times (seconds) of the verification process
+func NewBaseline(times []float64, threshold float64) Baseline {
+	b := Baseline{Threshold: threshold}
+	if len(times) == 0 {
+		return b
+	}
+	for _, t := range times {
+		b.Mean += t
+	}
+	b.Mean /= float64(len(times))
+	for _, t := range times {
+		b.StdDev += (t - b.Mean) * (t - b.Mean)
+	}
+	b.StdDev = math.Sqrt(b.StdDev / float64(len(times)))
+	return b
+}
+
+// Check if the response time is significantly above the baseline
+func (b Baseline) IsAnomaly(t float64) bool {
+	return t > b.Mean+math.Max(DEVIATION_FACTOR*b.StdDev, b.Threshold)
+}
+
+// Scale the delay within the payload by the factor (Ex: "sleep(5)" => "sleep(10)").
+// Return the scaled payload, the original delay and the scaled delay. False is returned in case the payload has no delay.
+func ScalePayload(payload string, factor int) (string, float64, float64, bool) {
+	m := regexDelay.FindStringSubmatchIndex(payload)
+	if m == nil {
+		return payload, 0, 0, false
+	}
+	value := payload[m[4]:m[5]]
+	delay, err := parseDelay(value)
+	if err != nil || delay <= 0 {
+		return payload, 0, 0, false
+	}
+	scaled := delay * factor
+	scaledValue, ok := formatDelay(scaled, strings.Contains(value, ":"))
+	if !ok {
+		return payload, 0, 0, false
+	}
+	return payload[:m[4]] + scaledValue + payload[m[5]:], float64(delay), float64(scaled), true
+}
+
+// Get the delay in seconds from a number or a time (Ex: "5" or "0:1:30" in "WAITFOR DELAY '0:1:30'")
+func parseDelay(value string) (int, error) {
+	var delay int
+	for _, field := range strings.Split(value, ":") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, err
+		}
+		delay = delay*60 + n
+	}
+	return delay, nil
+}
+
+// Format the delay in seconds as a number or a time ("h:m:s") in case "clock" is true.
+// Return false in case the time is not valid (Ex: a delay of a day or more)
+func formatDelay(delay int, clock bool) (string, bool) {
+	if !clock {
+		return strconv.Itoa(delay), true
+	}
+	if delay >= 24*60*60 {
+		return "", false
+	}
+	return fmt.Sprintf("%d:%d:%d", delay/3600, delay/60%60, delay%60), true
+}
+
+// Check if the response times of two delays are linear to the delays (the extra time is scaled as the delay).
+// Note : (The extra time is the response time above the baseline mean)
+func (b Baseline) IsLinear(first, second Probe) bool {
+	var (
+		extraFirst  = first.Time - b.Mean
+		extraSecond = second.Time - b.Mean
+	)
+	if first.Delay <= 0 || extraFirst <= 0 || extraSecond <= 0 {
+		return false
+	}
+	ratio := (extraSecond / extraFirst) / (second.Delay / first.Delay)
+	return math.Abs(ratio-1) <= LINEAR_TOLERANCE
+}
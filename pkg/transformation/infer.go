@@ -0,0 +1,243 @@
+package transformation
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Categories of the transformations that can be inferred
+const (
+	HTML_ENCODE   = "html-encode"
+	HTML_DECODE   = "html-decode"
+	URL_DECODE    = "url-decode"
+	DOUBLE_DECODE = "double-decode"
+	CASE_FOLD     = "case-fold"
+	NFKC          = "nfkc"
+	TRUNCATE      = "truncate"
+	STRIP         = "strip"
+	// A character converted to one that looks like it (Ex: "‹" => "<"), only reported for the Unicode variants
+	BEST_FIT = "best-fit"
+)
+
+var (
+	// Maximum amount of transformations (applied in a row) tried to explain a reflected payload
+	MAX_STEPS = 3
+
+	reEntity      = regexp.MustCompile(`&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+	reEntityStart = regexp.MustCompile(`^` + reEntity.String())
+	reURLEscape   = regexp.MustCompile(`%[0-9a-fA-F]{2}`)
+	reURLDouble   = regexp.MustCompile(`%25[0-9a-fA-F]{2}|%[0-9a-fA-F]{2}`)
+)
+
+// A step is one transformation applied by the target to the payload
+type Step struct {
+	Category string `json:"Category"`
+	// What each character (or sequence) of the payload became within this step. An empty value means that it was removed.
+	Mapping map[string]string `json:"Mapping"`
+}
+
+// Transformations applied by the target before the payload is reflected (Ex: url-decode).
+// The output encodings and the characters removed are not predictable and are instead checked against the reflected payload.
+type transform struct {
+	category string
+	apply    func(string) string
+	mapping  func(in string) map[string]string
+}
+
+var transforms = []transform{
+	{URL_DECODE, urlDecode, func(in string) map[string]string { return tokenMapping(in, reURLEscape, urlDecode) }},
+	{HTML_DECODE, html.UnescapeString, func(in string) map[string]string { return tokenMapping(in, reEntity, html.UnescapeString) }},
+	{CASE_FOLD, strings.ToLower, func(in string) map[string]string { return runeMapping(in, strings.ToLower) }},
+	{CASE_FOLD, strings.ToUpper, func(in string) map[string]string { return runeMapping(in, strings.ToUpper) }},
+	{NFKC, norm.NFKC.String, func(in string) map[string]string { return runeMapping(in, norm.NFKC.String) }},
+}
+
+// Infer the transformations that turned the sent payload into the reflected payload.
+// The steps are returned in the order they were applied by the target (Ex: url-decode => html-encode).
+// Note : (The exact explanations with the fewest steps are preferred before the truncation and stripping of characters)
+func Infer(sent, reflected string) ([]Step, bool) {
+	if sent == reflected {
+		return nil, false
+	}
+
+	type state struct {
+		s     string
+		chain []int
+	}
+	var (
+		states = []state{{s: sent}}
+		seen   = map[string]bool{sent: true}
+	)
+	for i := 0; i < len(states); i++ {
+		cur := states[i]
+		if len(cur.chain) >= MAX_STEPS {
+			continue
+		}
+		for n, t := range transforms {
+			s := t.apply(cur.s)
+			if seen[s] {
+				continue
+			}
+			seen[s] = true
+			chain := append(append([]int{}, cur.chain...), n)
+			states = append(states, state{s: s, chain: chain})
+		}
+	}
+
+	for _, loose := range []bool{false, true} {
+		for _, st := range states {
+			if steps, ok := lastSteps(st.s, reflected, loose); ok {
+				return append(chainSteps(sent, st.chain), steps...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Describe the steps by their category (Ex: "url-decode > html-encode")
+func Describe(steps []Step) string {
+	var lst []string
+	for _, s := range steps {
+		lst = append(lst, s.Category)
+	}
+	return strings.Join(lst, " > ")
+}
+
+// Get the step with its mapping sorted (Ex: `url-decode ["%3C" => "<", "%3E" => ">"]`)
+func (s Step) String() string {
+	var keys []string
+	for k := range s.Mapping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var lst []string
+	for _, k := range keys {
+		lst = append(lst, strconv.Quote(k)+" => "+strconv.Quote(s.Mapping[k]))
+	}
+	return s.Category + " [" + strings.Join(lst, ", ") + "]"
+}
+
+// Check if the (already transformed) payload become the reflected payload by the output encoding and/or the removal of characters
+func lastSteps(s, reflected string, loose bool) ([]Step, bool) {
+	decoded := html.UnescapeString(reflected)
+	encode := func() Step {
+		return Step{Category: HTML_ENCODE, Mapping: htmlEncodeMapping(decoded, reflected)}
+	}
+
+	if !loose {
+		if s == reflected {
+			return nil, true
+		} else if s == decoded {
+			return []Step{encode()}, true
+		}
+		return nil, false
+	}
+
+	for _, r := range []string{reflected, decoded} {
+		var steps []Step
+		if len(r) == 0 {
+			continue
+		} else if len(r) < len(s) && strings.HasPrefix(s, r) {
+			steps = []Step{{Category: TRUNCATE, Mapping: map[string]string{s[len(r):]: ""}}}
+		} else if m, ok := stripMapping(s, r); ok {
+			steps = []Step{{Category: STRIP, Mapping: m}}
+		} else {
+			continue
+		}
+		if r != reflected {
+			steps = append(steps, encode())
+		}
+		return steps, true
+	}
+	return nil, false
+}
+
+// Make the steps of the transformations in the chain. Two URL decodes in a row are a double decoding.
+func chainSteps(s string, chain []int) []Step {
+	var steps []Step
+	for i := 0; i < len(chain); i++ {
+		t := transforms[chain[i]]
+		if t.category == URL_DECODE && i+1 < len(chain) && transforms[chain[i+1]].category == URL_DECODE {
+			steps = append(steps, Step{
+				Category: DOUBLE_DECODE,
+				Mapping:  tokenMapping(s, reURLDouble, func(s string) string { return urlDecode(urlDecode(s)) }),
+			})
+			s = urlDecode(urlDecode(s))
+			i++
+			continue
+		}
+		steps = append(steps, Step{Category: t.category, Mapping: t.mapping(s)})
+		s = t.apply(s)
+	}
+	return steps
+}
+
+// Decode the URL escaped characters (the invalid escapes are kept as they are)
+func urlDecode(s string) string {
+	return reURLEscape.ReplaceAllStringFunc(s, func(e string) string {
+		b, _ := strconv.ParseUint(e[1:], 16, 8)
+		return string([]byte{byte(b)})
+	})
+}
+
+// Map each token (Ex: an HTML entity) to what it's decoded to
+func tokenMapping(s string, re *regexp.Regexp, decode func(string) string) map[string]string {
+	m := make(map[string]string)
+	for _, token := range re.FindAllString(s, -1) {
+		if d := decode(token); d != token {
+			m[token] = d
+		}
+	}
+	return m
+}
+
+// Map each character that is changed by the function (Ex: "A" => "a")
+func runeMapping(s string, f func(string) string) map[string]string {
+	m := make(map[string]string)
+	for _, r := range s {
+		if c := f(string(r)); c != string(r) {
+			m[string(r)] = c
+		}
+	}
+	return m
+}
+
+// Map each character that was HTML encoded to its entity (Ex: "<" => "&lt;")
+func htmlEncodeMapping(decoded, encoded string) map[string]string {
+	m := make(map[string]string)
+	for i, j := 0, 0; i < len(decoded) && j < len(encoded); {
+		r, n := utf8.DecodeRuneInString(decoded[i:])
+		if e := reEntityStart.FindString(encoded[j:]); len(e) > 0 && html.UnescapeString(e) == string(r) {
+			m[string(r)] = e
+			j += len(e)
+		} else {
+			j += n
+		}
+		i += n
+	}
+	return m
+}
+
+// Check if the reflected payload is the payload with some characters removed and map each removed character (Ex: "'" => "")
+func stripMapping(s, reflected string) (map[string]string, bool) {
+	var (
+		m = make(map[string]string)
+		r = []rune(reflected)
+		j = 0
+	)
+	for _, c := range s {
+		if j < len(r) && c == r[j] {
+			j++
+		} else {
+			m[string(c)] = ""
+		}
+	}
+	return m, j == len(r) && len(m) > 0
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"io/ioutil"
 	"regexp"
+	"strings"
 
+	"github.com/Brum3ns/firefly/pkg/snippet"
 	"gopkg.in/yaml.v2"
 )
 
@@ -16,6 +18,10 @@ var (
 type Transformation struct {
 	Storage map[string][2]string //(Expected payload[Transformed payload|Description])
 	Regex   *regexp.Regexp
+	// Unicode variants sent as payloads (Variant|Variant info)
+	Variants map[string]Variant
+	// Amount of characters of context taken on each side of the transformed payload (zero to not take any context)
+	Context int
 }
 
 type Properties struct {
@@ -26,6 +32,10 @@ type Result struct {
 	Desc    string
 	Payload string
 	Format  string
+	// Transformations applied to the payload in order with what each character became (Ex: url-decode => html-encode)
+	Steps []Step `json:",omitempty"`
+	// The transformed payload within the response body with the text around it
+	Context *snippet.Snippet `json:",omitempty"`
 }
 
 // Create a new transformation
@@ -41,8 +51,8 @@ func NewTransformation(yamlFile string) (Transformation, error) {
 }
 
 func (t Transformation) Detect(body, payload string) Result {
-	reflectedPayloads := t.Regex.FindAllString(body, -1)
-	if reflectedPayloads == nil {
+	reflectedPayloads := t.Regex.FindAllStringIndex(body, -1)
+	if reflectedPayloads == nil || !hasPrefixSuffix(payload) {
 		return Result{OK: false}
 	}
 
@@ -53,21 +63,58 @@ func (t Transformation) Detect(body, payload string) Result {
 		desc := arr[1]
 
 		//Check if any valid transformation was discovered from all the reflected payload patterns:
-		for _, i := range reflectedPayloads {
-			transformationPayload := RmPrefixSuffix(i)
+		for _, idx := range reflectedPayloads {
+			transformationPayload := RmPrefixSuffix(body[idx[0]:idx[1]])
 			if transformationPayload == expectedPayload {
-				return Result{
-					OK:      true,
-					Desc:    desc,
-					Payload: payload,
-					Format:  transformationPayload,
+				steps, _ := Infer(payload, transformationPayload)
+				return t.result(body, idx, payload, transformationPayload, desc, steps)
+			}
+		}
+	}
+
+	//Check if the Unicode variant collapsed to its ASCII character:
+	if v, ok := t.Variants[payload]; ok {
+		for _, idx := range reflectedPayloads {
+			transformationPayload := RmPrefixSuffix(body[idx[0]:idx[1]])
+			if transformationPayload == string(v.Char) {
+				steps, ok := Infer(payload, transformationPayload)
+				if !ok {
+					steps = []Step{{Category: BEST_FIT, Mapping: map[string]string{payload: transformationPayload}}}
 				}
+				return t.result(body, idx, payload, transformationPayload, v.String(), steps)
 			}
 		}
 	}
+
+	//Infer the transformation when the reflected payload is not an expected one:
+	for _, idx := range reflectedPayloads {
+		transformationPayload := RmPrefixSuffix(body[idx[0]:idx[1]])
+		if steps, ok := Infer(payload, transformationPayload); ok {
+			return t.result(body, idx, payload, transformationPayload, "inferred "+Describe(steps), steps)
+		}
+	}
 	return Result{OK: false}
 }
 
+func (t Transformation) result(body string, idx []int, payload, format, desc string, steps []Step) Result {
+	result := Result{
+		OK:      true,
+		Desc:    desc,
+		Payload: payload,
+		Format:  format,
+		Steps:   steps,
+	}
+	if t.Context > 0 {
+		s := snippet.New("body", body, idx[0], idx[1], t.Context)
+		result.Context = &s
+	}
+	return result
+}
+
+func hasPrefixSuffix(s string) bool {
+	return len(s) >= len(PREFIX)+len(SUFFIX) && strings.HasPrefix(s, PREFIX) && strings.HasSuffix(s, SUFFIX)
+}
+
 func RmPrefixSuffix(s string) string {
 	return s[len(PREFIX):(len(s) - len(SUFFIX))]
 }
@@ -0,0 +1,110 @@
+package transformation
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Kinds of the Unicode variants of a character
+const (
+	// The variant is normalized (NFKC) to the character (Ex: fullwidth "＜" => "<")
+	UNICODE_COMPATIBILITY = "unicode-compatibility"
+	// The variant looks like the character and may be converted to it by a "best-fit" mapping (Ex: "‹" => "<")
+	UNICODE_CONFUSABLE = "unicode-confusable"
+)
+
+// Characters that look like the special characters and that are known to be converted to them by the "best-fit" mappings of the code pages.
+// Note : (The compatibility variants are found from the Unicode normalization instead and are not listed here)
+var CONFUSABLES = map[rune][]rune{
+	'<':  {'‹', '˂', 'ᐸ'},
+	'>':  {'›', '˃', 'ᐳ'},
+	'\'': {'ʼ', '‘', '’', 'ʹ', '′', 'ˈ'},
+	'"':  {'“', '”', 'ʺ', '˝'},
+	'/':  {'∕', '⁄'},
+	'\\': {'∖', '⧵'},
+	'-':  {'‐', '−', '–', '—'},
+	'%':  {'٪'},
+	'*':  {'∗', '⁎'},
+	',':  {'‚'},
+	':':  {'∶', 'ː'},
+	'|':  {'∣', 'ǀ'},
+	'~':  {'˜', '∼'},
+	'^':  {'ˆ'},
+	'!':  {'ǃ'},
+	'(':  {'❨'},
+	')':  {'❩'},
+	'{':  {'❴'},
+	'}':  {'❵'},
+	'_':  {'ˍ'},
+}
+
+// A Unicode variant that may collapse to an ASCII character
+type Variant struct {
+	Char    rune   `json:"Char"`
+	Variant rune   `json:"Variant"`
+	Kind    string `json:"Kind"`
+}
+
+// Describe the variant (Ex: "unicode-compatibility U+FF1C")
+func (v Variant) String() string {
+	return fmt.Sprintf("%s U+%04X", v.Kind, v.Variant)
+}
+
+// Get the compatibility and confusable variants of the characters (Ex: payloads.DEFAULT_CHARS)
+func UnicodeVariants(chars []rune) []Variant {
+	var (
+		lst  []Variant
+		seen = make(map[rune]bool)
+		want = make(map[string]rune)
+	)
+	for _, c := range chars {
+		want[string(c)] = c
+	}
+
+	// Compatibility variants within the basic multilingual plane (the plane of all the punctuation variants):
+	for r := rune(0x80); r <= 0xFFFF; r++ {
+		if !unicode.IsPrint(r) {
+			continue
+		}
+		if c, ok := want[norm.NFKC.String(string(r))]; ok {
+			lst = append(lst, Variant{Char: c, Variant: r, Kind: UNICODE_COMPATIBILITY})
+			seen[r] = true
+		}
+	}
+
+	for _, c := range chars {
+		for _, r := range CONFUSABLES[c] {
+			if !seen[r] {
+				lst = append(lst, Variant{Char: c, Variant: r, Kind: UNICODE_CONFUSABLE})
+				seen[r] = true
+			}
+		}
+	}
+
+	sort.SliceStable(lst, func(i, j int) bool {
+		return lst[i].Char < lst[j].Char
+	})
+	return lst
+}
+
+// Get the transformation payloads of the variants (one variant within the prefix and suffix per payload)
+func VariantWordlist(variants []Variant) []string {
+	var wordlist []string
+	for _, v := range variants {
+		wordlist = append(wordlist, (PREFIX + string(v.Variant) + SUFFIX))
+	}
+	return wordlist
+}
+
+// Add the variants to detect when they collapse to their ASCII character
+func (t *Transformation) AddVariants(variants []Variant) {
+	if t.Variants == nil {
+		t.Variants = make(map[string]Variant)
+	}
+	for _, v := range variants {
+		t.Variants[string(v.Variant)] = v
+	}
+}
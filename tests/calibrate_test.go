@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/httpfilter"
+)
+
+func Test_Calibration(t *testing.T) {
+	// The size differ (the payload is reflected) but the word count is the same in all the baseline responses:
+	baseline := []httpfilter.Response{
+		{StatusCode: 200, ResponseSize: 1020, WordCount: 80, LineCount: 10},
+		{StatusCode: 200, ResponseSize: 1028, WordCount: 80, LineCount: 10},
+		{StatusCode: 200, ResponseSize: 1036, WordCount: 80, LineCount: 11},
+	}
+	calibration, ok := httpfilter.NewCalibration(baseline)
+	if !ok {
+		t.Fatal("the baseline responses must be calibrated")
+	}
+	for _, c := range []struct {
+		resp httpfilter.Response
+		want bool
+	}{
+		{httpfilter.Response{StatusCode: 200, ResponseSize: 999, WordCount: 80}, true},
+		{httpfilter.Response{StatusCode: 200, ResponseSize: 1020, WordCount: 95}, false},
+		{httpfilter.Response{StatusCode: 500, ResponseSize: 1020, WordCount: 80}, false},
+	} {
+		if got := calibration.Run(c.resp); got != c.want {
+			t.Errorf("%+v: got %v, want %v", c.resp, got, c.want)
+		}
+	}
+
+	// The words and lines are stable, a response must match both to be filtered:
+	calibration, _ = httpfilter.NewCalibration([]httpfilter.Response{
+		{StatusCode: 200, ResponseSize: 1020, WordCount: 80, LineCount: 10},
+		{StatusCode: 200, ResponseSize: 1028, WordCount: 80, LineCount: 10},
+	})
+	for _, c := range []struct {
+		resp httpfilter.Response
+		want bool
+	}{
+		{httpfilter.Response{StatusCode: 200, ResponseSize: 999, WordCount: 80, LineCount: 10}, true},
+		{httpfilter.Response{StatusCode: 200, ResponseSize: 1020, WordCount: 80, LineCount: 42}, false},
+		{httpfilter.Response{StatusCode: 200, ResponseSize: 1020, WordCount: 42, LineCount: 10}, false},
+	} {
+		if got := calibration.Run(c.resp); got != c.want {
+			t.Errorf("%+v: got %v, want %v", c.resp, got, c.want)
+		}
+	}
+	if s := calibration.String(); s != "status:[200] words:80 lines:10" {
+		t.Errorf("unexpected calibration %q", s)
+	}
+
+	if _, ok := httpfilter.NewCalibration([]httpfilter.Response{
+		{StatusCode: 200, ResponseSize: 10, WordCount: 1, LineCount: 1},
+		{StatusCode: 200, ResponseSize: 20, WordCount: 2, LineCount: 2},
+	}); ok {
+		t.Error("responses without a stable property can't be calibrated")
+	}
+}
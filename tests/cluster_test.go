@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Brum3ns/firefly/internal/output"
+)
+
+func Test_ClusterResults(t *testing.T) {
+	result := func(id int, payload, url string, transformed bool) output.ResultFinal {
+		r := output.ResultFinal{OK: true, RequestId: id, TargetHashId: "target", Payload: payload}
+		r.Request.Method = "GET"
+		r.Request.URLOriginal = url
+		r.Scanner.Transformation.OK = transformed
+		return r
+	}
+	results := []output.ResultFinal{
+		result(1, "'", "http://example.com/?a=FUZZ", true),
+		result(2, "\"", "http://example.com/?a=FUZZ", true),
+		result(3, "'", "http://example.com/?a=FUZZ", true),
+		// Other technique and other insert point:
+		result(4, "'", "http://example.com/?a=FUZZ", false),
+		result(5, "'", "http://example.com/?b=FUZZ", true),
+	}
+
+	clusters := output.ClusterResults(results)
+	if len(clusters) != 3 {
+		t.Fatalf("got %d clusters, want 3", len(clusters))
+	}
+	first := clusters[0]
+	if first.RequestId != 1 || first.Cluster == nil || first.Cluster.Count != 3 {
+		t.Fatalf("unexpected representative of the first cluster: %+v", first)
+	}
+	if len(first.Cluster.Payloads) != 2 || first.Cluster.Payloads[0] != "'" || first.Cluster.Payloads[1] != "\"" {
+		t.Errorf("unexpected example payloads: %v", first.Cluster.Payloads)
+	}
+	if clusters[1].RequestId != 4 || clusters[2].RequestId != 5 || clusters[2].Cluster.Count != 1 {
+		t.Errorf("unexpected clusters: %+v", clusters[1:])
+	}
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/expression"
+)
+
+func Test_Expression(t *testing.T) {
+	env := expression.Env{
+		"status":          302,
+		"words":           64,
+		"time":            0.25,
+		"body":            "<h1>Welcome</h1>",
+		"header":          map[string]string{"server": "nginx", "content-type": "text/html"},
+		"transformation":  false,
+		"extract.pattern": map[string]int{"SQL syntax": 2},
+		"technique":       []string{"diff/header", "extract/pattern/SQL syntax"},
+	}
+	for expr, want := range map[string]bool{
+		`status in [200,302] && words > 50 && !body ~ "not found" && header["server"] == "nginx"`: true,
+		`status == 200 || (words >= 64 && time < 1)`:                                              true,
+		`!(status == 302)`: false,
+		`not transformation and extract.pattern["SQL syntax"] > 1`:        true,
+		`"diff/header" in technique && body contains "Welcome"`:           true,
+		`header["Content-Type"] !~ "^text/" || header["x-missing"] != ""`: false,
+		`status != 302 || words < 10 && body ~ "Welcome"`:                 false,
+		`missing`: false,
+	} {
+		e, err := expression.Compile(expr)
+		if err != nil {
+			t.Fatalf("%s: %s", expr, err)
+		}
+		if got := e.Eval(env); got != want {
+			t.Errorf("%s: got %v, want %v", expr, got, want)
+		}
+	}
+
+	for _, expr := range []string{`status ==`, `(status == 200`, `body ~ 1`, `body ~ "("`, `status in [200,`, `"unterminated`} {
+		if _, err := expression.Compile(expr); err == nil {
+			t.Errorf("%s: expected a syntax error", expr)
+		}
+	}
+}
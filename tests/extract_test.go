@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/extract"
+)
+
+func Test_Extract(t *testing.T) {
+	patterns := []string{"SQL syntax", "ORA-", "ORA-00933", "aa", "at", "<", "Warning: ", "SQL syntax"}
+	e, err := extract.NewExtract(extract.Properties{
+		Rules: extract.WordlistRules("test", patterns, []string{`mysqli?_[a-z_]+\(`, `line [0-9]+`}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := "<b>Warning: </b> mysqli_fetch_array() error in your SQL syntax near 'aaaa' ORA-00933 at line 12"
+	headers := "Server: nginx\r\nX-Error: ORA-01756\r\n"
+	e.AddJob(body, headers)
+	result := e.Run()
+
+	// The pattern hits must be the same as "strings.Count" (occurrences don't overlap):
+	for _, ptn := range patterns {
+		if got, want := result.PatternBody[ptn], strings.Count(body, ptn); got != want {
+			t.Errorf("pattern %q in body: got %d hits, want %d", ptn, got, want)
+		}
+	}
+	if result.PatternHeaders["ORA-"] != 1 || result.PatternHeaders["ORA-00933"] != 0 {
+		t.Errorf("unexpected header patterns %v", result.PatternHeaders)
+	}
+	if result.RegexBody[`mysqli?_[a-z_]+\(`] != 1 || result.RegexBody[`line [0-9]+`] != 1 || len(result.RegexHeaders) != 0 {
+		t.Errorf("unexpected regex hits %v %v", result.RegexBody, result.RegexHeaders)
+	}
+	if !result.OK {
+		t.Error("the result must be OK")
+	}
+
+	// The same structure is used for the next response:
+	e.AddJob("nothing to see", "Server: nginx")
+	if result := e.Run(); result.OK || result.TotalHits != 0 {
+		t.Errorf("unexpected hits %+v", result)
+	}
+
+	if _, err := extract.NewExtract(extract.Properties{Rules: extract.WordlistRules("test", nil, []string{"(unclosed"})}); err == nil {
+		t.Error("an invalid regex must return an error")
+	}
+
+	// Only the items that are not known (and not within the payload) are kept:
+	unique, hits := extract.GetUnique(map[string]int{"ORA-": 2, "SQL syntax": 1, "<": 1}, map[string][]int{"SQL syntax": {1}}, "'<")
+	if hits != 2 || len(unique) != 1 || unique["ORA-"] != 2 {
+		t.Errorf("unexpected unique items %v (%d hits)", unique, hits)
+	}
+}
+
+func Test_ExtractRules(t *testing.T) {
+	rules, err := extract.ParseRules([]byte(`
+- id: oracle-ora-00933
+  pattern: "ORA-00933"
+  category: sql-error
+  severity: high
+  match: body
+  cwe: CWE-209
+- id: php-version
+  regex: "X-Powered-By: PHP/[0-9.]+"
+  category: version-disclosure
+  match: header
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := rules[1]; r.Severity != "info" || r.Match != extract.MATCH_HEADER {
+		t.Errorf("unexpected default values of the rule %+v", r)
+	}
+
+	e, err := extract.NewExtract(extract.Properties{Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.AddJob("ORA-00933: SQL command not properly ended", "X-Powered-By: PHP/8.1.2\r\nX-Error: ORA-00933")
+	result := e.Run()
+	if result.PatternBody["ORA-00933"] != 1 || len(result.PatternHeaders) != 0 || result.RegexHeaders["X-Powered-By: PHP/[0-9.]+"] != 1 {
+		t.Fatalf("the rules were not matched within their source: %+v", result)
+	}
+	if r := result.Rules["ORA-00933"]; r.Id != "oracle-ora-00933" || r.Category != "sql-error" || r.CWE != "CWE-209" {
+		t.Errorf("unexpected rule metadata %+v", r)
+	}
+
+	for _, invalid := range []string{
+		"- id: both\n  pattern: a\n  regex: b",
+		"- id: severity\n  pattern: a\n  severity: urgent",
+		"- id: match\n  pattern: a\n  match: cookie",
+	} {
+		if _, err := extract.ParseRules([]byte(invalid)); err == nil {
+			t.Errorf("the rule must be invalid: %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/pkg/parameter"
+)
+
+// Write the content to a file within a temporary folder and return its path
+func writeFixture(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Write the results with a new output writer (format taken from the file extension) and return the content of the output file
+func writeOutput(t *testing.T, name string, results []output.ResultFinal) []byte {
+	path := filepath.Join(t.TempDir(), name)
+	w, err := output.NewWriter(path, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file of %s must be renamed", name)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// Make the amount of OK results (request id 1 to n) with a transformation finding
+func newResults(n int) []output.ResultFinal {
+	var results []output.ResultFinal
+	for id := 1; id <= n; id++ {
+		r := output.ResultFinal{OK: true, RequestId: id, Payload: "'"}
+		r.Request.URL = "http://example.com/?q='"
+		r.Request.Method = "GET"
+		r.Response.StatusCode = 500
+		r.Scanner.Transformation.OK = true
+		r.Scanner.Transformation.Desc = "HTML encode"
+		results = append(results, r)
+	}
+	return results
+}
+
+// Make a parameter with the rule (replace/append) for the position
+func newParameter(t *testing.T, position, method string) parameter.Parameter {
+	p, err := parameter.NewParameter(map[string]parameter.QueryRules{position: {Method: method}}, "FUZZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/scope"
+)
+
+func Test_ImportHAR(t *testing.T) {
+	har := `{"log": {"entries": [
+	{"request": {
+		"method": "post",
+		"url": "https://example.com/login?next=/home",
+		"headers": [
+			{"name": ":authority", "value": "example.com"},
+			{"name": "Host", "value": "example.com"},
+			{"name": "Content-Length", "value": "19"},
+			{"name": "X-Token", "value": "abc"}
+		],
+		"cookies": [{"name": "sid", "value": "1"}],
+		"postData": {"mimeType": "application/x-www-form-urlencoded", "text": "user=admin&pass=123"}
+	}},
+	{"request": {
+		"method": "PUT",
+		"url": "https://example.com/api",
+		"headers": [{"name": "Cookie", "value": "sid=2"}],
+		"cookies": [{"name": "sid", "value": "2"}],
+		"postData": {"mimeType": "application/json", "params": [{"name": "a", "value": "1"}, {"name": "b", "value": "2"}]}
+	}},
+	{"request": {"method": "GET", "url": ""}}
+]}}`
+	targets, err := scope.ReadHAR(writeFixture(t, "session.har", har))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []scope.Target{
+		{
+			URL:     "https://example.com/login?next=/home",
+			Method:  "POST",
+			Headers: [][2]string{{"x-token", "abc"}, {"content-type", "application/x-www-form-urlencoded"}},
+			Body:    "user=admin&pass=123",
+			Cookies: map[string]string{"sid": "1"},
+		},
+		{
+			URL:     "https://example.com/api",
+			Method:  "PUT",
+			Headers: [][2]string{{"cookie", "sid=2"}, {"content-type", "application/json"}},
+			Body:    "a=1&b=2",
+		},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("got the targets\n%+v\nwant\n%+v", targets, want)
+	}
+	if p := scope.AutoParams(targets[0], "replace"); p != "url:replace,body:replace,cookie:replace" {
+		t.Errorf("unexpected auto parameters %q", p)
+	}
+
+	if _, err := scope.ReadHAR(writeFixture(t, "invalid.har", `{"log": [`)); err == nil {
+		t.Error("an error was expected for an invalid HAR file")
+	}
+}
+
+func Test_ImportBurp(t *testing.T) {
+	raw := "POST /api/users?id=1 HTTP/1.1\r\nHost: example.com\r\nContent-Type: application/json\r\nContent-Length: 14\r\nCookie: sid=1\r\n\r\n{\"name\":\"bob\"}"
+	xml := `<?xml version="1.0"?>
+<items burpVersion="2023.1">
+  <item>
+    <url><![CDATA[https://example.com/api/users?id=1]]></url>
+    <protocol>https</protocol>
+    <method><![CDATA[POST]]></method>
+    <request base64="true"><![CDATA[` + base64.StdEncoding.EncodeToString([]byte(raw)) + `]]></request>
+  </item>
+  <item>
+    <url><![CDATA[]]></url>
+    <protocol>http</protocol>
+    <method><![CDATA[GET]]></method>
+    <request base64="false"><![CDATA[GET /search?q=x HTTP/1.1
+Host: shop.example.com
+X-Custom: 1
+
+]]></request>
+  </item>
+</items>`
+	targets, err := scope.ReadBurp(writeFixture(t, "burp.xml", xml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []scope.Target{
+		{
+			URL:     "https://example.com/api/users?id=1",
+			Method:  "POST",
+			Headers: [][2]string{{"content-type", "application/json"}, {"cookie", "sid=1"}},
+			Body:    `{"name":"bob"}`,
+		},
+		{
+			// Without an item URL, the URL is made from the host header and the protocol:
+			URL:     "http://shop.example.com/search?q=x",
+			Method:  "GET",
+			Headers: [][2]string{{"x-custom", "1"}},
+		},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("got the targets\n%+v\nwant\n%+v", targets, want)
+	}
+	if p := scope.AutoParams(targets[0], "append"); p != "url:append,json:append,cookie:append" {
+		t.Errorf("unexpected auto parameters %q", p)
+	}
+
+	invalid := `<items><item><request base64="true">not base64!</request></item></items>`
+	if _, err := scope.ReadBurp(writeFixture(t, "invalid.xml", invalid)); err == nil {
+		t.Error("an error was expected for an invalid base64 request")
+	}
+}
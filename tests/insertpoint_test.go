@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
+)
+
+func Test_AttackStrategies(t *testing.T) {
+	points := []insertpoint.Point{
+		{Keyword: "FUZZ1", Original: "o1", Payloads: []string{"a", "b"}},
+		{Keyword: "FUZZ2", Original: "o2", Payloads: []string{"1", "2", "3"}},
+	}
+	expected := map[string][]string{
+		insertpoint.ATTACK_SNIPER:      {"a,o2", "b,o2", "o1,1", "o1,2", "o1,3"},
+		insertpoint.ATTACK_PITCHFORK:   {"a,1", "b,2"},
+		insertpoint.ATTACK_CLUSTERBOMB: {"a,1", "a,2", "a,3", "b,1", "b,2", "b,3"},
+	}
+
+	for strategy, want := range expected {
+		sets, err := insertpoint.Attack(strategy, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, set := range sets {
+			got = append(got, strings.Join(set.Payloads, ","))
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%s: got %v, want %v", strategy, got, want)
+		}
+	}
+
+	// The keyword "FUZZ" must not break the named insert point "FUZZ1":
+	sets, _ := insertpoint.Attack(insertpoint.ATTACK_SNIPER, points)
+	insert := insertpoint.NewInsertPoints("FUZZ", points, sets[0])
+	if s := insert.SetPostBody("x=FUZZ&y=FUZZ1&z=FUZZ2"); s != "x=a&y=a&z=o2" {
+		t.Errorf("unexpected post body: %s", s)
+	}
+}
@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"encoding/binary"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
+	"github.com/Brum3ns/firefly/pkg/oob"
+	"github.com/Brum3ns/firefly/pkg/random"
+)
+
+func Test_OOB(t *testing.T) {
+	server, err := oob.NewServer(oob.Config{
+		Domain:      "oob.example.com",
+		HTTPAddress: "127.0.0.1:0",
+		DNSAddress:  "127.0.0.1:0",
+		IP:          "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+
+	// Each host made for the request is collected to be assigned to the request:
+	var ids []string
+	newHost := func() string {
+		host, id := server.Host()
+		ids = append(ids, id)
+		return host
+	}
+	insert := insertpoint.NewInsert("FUZZ", "http://#OOB#/x?a=#OOB#").OOBInsert(newHost)
+	payload := insert.Payload
+	host, _, _ := strings.Cut(strings.TrimPrefix(payload, "http://"), "/")
+	if !strings.HasSuffix(host, ".oob.example.com") || strings.Count(payload, host) != 2 {
+		t.Fatalf("unexpected OOB host in %q", payload)
+	}
+	// The keyword within the request is replaced as well:
+	if u := insert.SetURL("http://example.com/?u=http://#OOB#/"); strings.Contains(u, random.OOB_KEYWORD) || len(ids) != 2 {
+		t.Fatalf("unexpected OOB host in the URL %q (%d hosts)", u, len(ids))
+	}
+	server.Assign(1337, ids)
+
+	// Without the OOB server the keyword is kept:
+	if p := insertpoint.NewInsert("FUZZ", "http://#OOB#/").OOBInsert(nil).Payload; p != "http://#OOB#/" {
+		t.Errorf("unexpected payload without the OOB server %q", p)
+	}
+
+	// HTTP interaction (the host header contains the unique host):
+	req, _ := http.NewRequest("GET", "http://"+server.HTTPAddress()+"/ssrf", nil)
+	req.Host = host
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	expectInteraction(t, server, oob.PROTOCOL_HTTP, 1337)
+
+	// DNS interaction (a subdomain of the unique host):
+	conn, err := net.Dial("udp", server.DNSAddress())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write(dnsQuery("data." + host))
+	answer := make([]byte, 512)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(answer)
+	if err != nil || n < 4 || binary.BigEndian.Uint16(answer[6:8]) != 1 || !net.IP(answer[n-4:n]).Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("invalid DNS answer (%v): %x", err, answer[:n])
+	}
+	expectInteraction(t, server, oob.PROTOCOL_DNS, 1337)
+
+	// Unknown ids are not interactions:
+	resp, err = http.Get("http://" + server.HTTPAddress() + "/unknown")
+	if err == nil {
+		resp.Body.Close()
+	}
+	select {
+	case i := <-server.Interactions:
+		t.Errorf("unexpected interaction %+v", i)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func expectInteraction(t *testing.T, server *oob.Server, protocol string, requestId int) {
+	select {
+	case i := <-server.Interactions:
+		if i.Protocol != protocol || i.RequestId != requestId {
+			t.Errorf("got the interaction %+v, want the protocol %s and request id %d", i, protocol, requestId)
+		}
+	case <-time.After(2 * time.Second):
+		t.Errorf("no %s interaction received", protocol)
+	}
+}
+
+// Make a DNS query (type A) for the name
+func dnsQuery(name string) []byte {
+	msg := []byte{0x13, 0x37, 0x01, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}
+	for _, label := range strings.Split(name, ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	return append(msg, 0, 0, 1, 0, 1)
+}
+
+func Test_OOBChannelFull(t *testing.T) {
+	server, err := oob.NewServer(oob.Config{HTTPAddress: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+
+	// More interactions than the channel buffer can hold:
+	n := cap(server.Interactions) + 1
+	var hosts, ids []string
+	for i := 0; i < n; i++ {
+		host, id := server.Host()
+		hosts, ids = append(hosts, host), append(ids, id)
+	}
+	server.Assign(1, ids)
+	client := &http.Client{Timeout: 5 * time.Second}
+	for _, host := range hosts {
+		go func(host string) {
+			if resp, err := client.Get("http://" + host); err == nil {
+				resp.Body.Close()
+			}
+		}(host)
+	}
+	for deadline := time.Now().Add(2 * time.Second); len(server.Interactions) < cap(server.Interactions); {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d interactions, want %d", len(server.Interactions), cap(server.Interactions))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// An interaction that waits for the channel must not block the server:
+	done := make(chan struct{})
+	go func() {
+		_, id := server.Host()
+		server.Assign(2, []string{id})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the server is blocked by the full interaction channel")
+	}
+
+	for i := 0; i < n; i++ {
+		expectInteraction(t, server, oob.PROTOCOL_HTTP, 1)
+	}
+	if err := server.Close(); err != nil {
+		t.Error(err)
+	}
+	if _, ok := <-server.Interactions; ok {
+		t.Error("the interaction channel must be closed")
+	}
+}
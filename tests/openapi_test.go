@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/scope"
+)
+
+func Test_ImportOpenAPI(t *testing.T) {
+	openAPI := `openapi: 3.0.0
+servers:
+  - url: /relative
+  - url: https://{env}.example.com/v1
+    variables:
+      env:
+        default: api
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+      - name: X-Trace
+        in: header
+    get:
+      parameters:
+        - name: fields
+          in: query
+        - name: session
+          in: cookie
+    put:
+      parameters:
+        - $ref: "#/components/parameters/Trace"
+      requestBody:
+        $ref: "#/components/requestBodies/User"
+  /login:
+    post:
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              properties:
+                user: {type: string}
+                pass: {type: string}
+components:
+  parameters:
+    Trace:
+      name: X-Trace
+      in: header
+  requestBodies:
+    User:
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/User"
+  schemas:
+    User:
+      allOf:
+        - $ref: "#/components/schemas/Base"
+        - properties:
+            name: {type: string, example: bob}
+            roles: {type: array, items: {type: string, enum: [admin, user]}}
+            manager: {$ref: "#/components/schemas/User"}
+    Base:
+      properties:
+        id: {type: integer}
+        active: {type: boolean}
+`
+	targets, err := scope.ReadOpenAPI(writeFixture(t, "openapi.yml", openAPI), "FUZZ", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []scope.Target{
+		{
+			URL:     "https://api.example.com/v1/login",
+			Method:  "POST",
+			Headers: [][2]string{{"content-type", "application/x-www-form-urlencoded"}},
+			Body:    "pass=FUZZ&user=FUZZ",
+		},
+		{
+			URL:     "https://api.example.com/v1/users/FUZZ?fields=FUZZ",
+			Method:  "GET",
+			Headers: [][2]string{{"x-trace", "FUZZ"}},
+			Cookies: map[string]string{"session": "FUZZ"},
+		},
+		{
+			// The recursive reference (manager) is not generated:
+			URL:        "https://api.example.com/v1/users/FUZZ",
+			Method:     "PUT",
+			Headers:    [][2]string{{"x-trace", "FUZZ"}, {"content-type", "application/json"}},
+			Body:       `{"active":true,"id":1,"name":"bob","roles":["admin"]}`,
+			AutoParams: "json:replace",
+		},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("got the targets\n%+v\nwant\n%+v", targets, want)
+	}
+
+	// The server given as argument replaces the servers of the document:
+	targets, err = scope.ReadOpenAPI(writeFixture(t, "openapi.yml", openAPI), "FUZZ", "http://127.0.0.1:8080/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if targets[0].URL != "http://127.0.0.1:8080/login" {
+		t.Errorf("unexpected URL with the server argument %q", targets[0].URL)
+	}
+}
+
+func Test_ImportSwagger(t *testing.T) {
+	swagger := `{
+  "swagger": "2.0",
+  "host": "example.com",
+  "basePath": "/api",
+  "schemes": ["http"],
+  "paths": {
+    "/items/{itemId}/tags/{tag}": {
+      "post": {
+        "parameters": [
+          {"name": "itemId", "in": "path"},
+          {"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Tag"}}
+        ]
+      },
+      "patch": {
+        "parameters": [
+          {"name": "note", "in": "formData"},
+          {"name": "q", "in": "query"}
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "Tag": {"type": "object", "properties": {"label": {"type": "string"}, "weight": {"type": "number"}}}
+  }
+}`
+	targets, err := scope.ReadOpenAPI(writeFixture(t, "swagger.json", swagger), "FUZZ", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The undeclared path parameter (tag) is still an insert point:
+	want := []scope.Target{
+		{
+			URL:        "http://example.com/api/items/FUZZ/tags/FUZZ",
+			Method:     "POST",
+			Headers:    [][2]string{{"content-type", "application/json"}},
+			Body:       `{"label":"string","weight":1.5}`,
+			AutoParams: "json:replace",
+		},
+		{
+			URL:     "http://example.com/api/items/FUZZ/tags/FUZZ?q=FUZZ",
+			Method:  "PATCH",
+			Headers: [][2]string{{"content-type", "application/x-www-form-urlencoded"}},
+			Body:    "note=FUZZ",
+		},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("got the targets\n%+v\nwant\n%+v", targets, want)
+	}
+
+	// Invalid documents:
+	for name, content := range map[string]string{
+		"no-version.json": `{"paths": {}}`,
+		"no-server.yml":   "openapi: 3.0.0\nservers:\n  - url: /api\npaths:\n  /a:\n    get: {}\n",
+		"no-paths.yml":    "openapi: 3.0.0\nservers:\n  - url: http://example.com\n",
+	} {
+		if _, err := scope.ReadOpenAPI(writeFixture(t, name, content), "FUZZ", ""); err == nil {
+			t.Errorf("%s: an error was expected", name)
+		}
+	}
+}
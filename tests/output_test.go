@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Brum3ns/firefly/internal/output"
+)
+
+func Test_WriterJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	w, err := output.NewWriter(path, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range newResults(3) {
+		r.Response.Body = "not included"
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+		// Results that are not OK are ignored:
+		if err := w.Write(output.ResultFinal{RequestId: 100 + i}); err != nil {
+			t.Fatal(err)
+		}
+
+		// The temporary file is a valid JSON array after each write:
+		var results []output.ResultFinal
+		data, _ := os.ReadFile(path + ".part")
+		if err := json.Unmarshal(data, &results); err != nil {
+			t.Fatalf("invalid JSON after %d writes: %s\n%s", i+1, err, data)
+		}
+		if len(results) != i+1 || results[i].RequestId != i+1 || results[i].Response.Body != "" {
+			t.Errorf("unexpected results after %d writes: %+v", i+1, results)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if w.Count() != 3 {
+		t.Errorf("got the count %d, want 3", w.Count())
+	}
+	if err := w.Write(newResults(1)[0]); err == nil {
+		t.Error("an error was expected when writing to a closed writer")
+	}
+	var results []output.ResultFinal
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &results); err != nil || len(results) != 3 {
+		t.Errorf("invalid JSON output after close (%v): %s", err, data)
+	}
+}
+
+func Test_WriterJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.jsonl")
+	w, err := output.NewWriter(path, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range newResults(3) {
+		r.Response.Body = "line 1\nline 2"
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(string(data), "\n") {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), data)
+	}
+	for idx, line := range lines {
+		var r output.ResultFinal
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid JSON line %d: %s", idx+1, err)
+		}
+		// The body is included:
+		if r.RequestId != idx+1 || r.Response.Body != "line 1\nline 2" {
+			t.Errorf("unexpected result on line %d: %+v", idx+1, r)
+		}
+	}
+
+	// The format can be given and is not taken from the file extension:
+	path = filepath.Join(t.TempDir(), "result.txt")
+	if w, err = output.NewWriter(path, "JSONL", false); err != nil {
+		t.Fatal(err)
+	}
+	w.Write(newResults(1)[0])
+	w.Close()
+	if data, _ := os.ReadFile(path); strings.Count(string(data), "\n") != 1 || data[0] != '{' {
+		t.Errorf("unexpected JSON Lines output %q", data)
+	}
+	if _, err := output.NewWriter(path, "yaml", false); err == nil {
+		t.Error("an error was expected for an unsupported format")
+	}
+}
+
+// The output file of each format is parsed once the writer is closed with 0, 1 and 3 results
+func Test_WriterDocuments(t *testing.T) {
+	for _, c := range []struct {
+		file  string
+		check func(t *testing.T, data []byte, n int)
+	}{
+		{"result.json", func(t *testing.T, data []byte, n int) {
+			var results []output.ResultFinal
+			if err := json.Unmarshal(data, &results); err != nil || len(results) != n {
+				t.Errorf("invalid JSON array (%v): %s", err, data)
+			}
+		}},
+		{"result.sarif", func(t *testing.T, data []byte, n int) {
+			var doc struct {
+				Version string `json:"version"`
+				Runs    []struct {
+					Results []struct {
+						RuleId string `json:"ruleId"`
+					} `json:"results"`
+				} `json:"runs"`
+			}
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("invalid SARIF document: %s", err)
+			}
+			if doc.Version != "2.1.0" || len(doc.Runs) != 1 || len(doc.Runs[0].Results) != n {
+				t.Fatalf("unexpected SARIF document: %+v", doc)
+			}
+			for _, r := range doc.Runs[0].Results {
+				if r.RuleId != "transformation/HTML encode" {
+					t.Errorf("unexpected SARIF rule %q", r.RuleId)
+				}
+			}
+		}},
+		{"report.html", func(t *testing.T, data []byte, n int) {
+			s := strings.TrimSpace(string(data))
+			if !strings.HasPrefix(s, "<!DOCTYPE html>") || !strings.HasSuffix(s, "</html>") || strings.Count(s, "<!DOCTYPE html>") != 1 {
+				t.Errorf("the HTML report is not a single complete document")
+			}
+			if n > 0 && !strings.Contains(s, "transformation/HTML encode") {
+				t.Errorf("the HTML report does not contain the findings")
+			}
+		}},
+		{"findings.csv", func(t *testing.T, data []byte, n int) {
+			records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+			if err != nil {
+				t.Fatalf("invalid CSV: %s", err)
+			}
+			// The header is written once and followed by one row for each result:
+			if len(records) != n+1 || records[0][0] != "RequestId" || records[0][len(records[0])-1] != "Transformation" {
+				t.Fatalf("unexpected CSV: %v", records)
+			}
+			for idx, record := range records[1:] {
+				if record[0] != strconv.Itoa(idx+1) || record[4] != "http://example.com/?q='" || record[len(record)-1] == "" {
+					t.Errorf("unexpected CSV row %d: %v", idx+1, record)
+				}
+			}
+		}},
+		{"findings.md", func(t *testing.T, data []byte, n int) {
+			s := string(data)
+			if !strings.HasPrefix(s, "# Firefly findings\n") || strings.Count(s, "# Firefly findings") != 1 {
+				t.Errorf("the Markdown title must be written once:\n%s", s)
+			}
+			if c := strings.Count(s, "\n## #"); c != n {
+				t.Errorf("got %d findings, want %d:\n%s", c, n, s)
+			}
+			for id := 1; id <= n; id++ {
+				if !strings.Contains(s, "## #"+strconv.Itoa(id)+" GET ") {
+					t.Errorf("the finding of request %d is missing", id)
+				}
+			}
+			if strings.Count(s, "```")%2 != 0 {
+				t.Errorf("unclosed code block in the Markdown output:\n%s", s)
+			}
+			if n == 0 && !strings.Contains(s, "No findings") {
+				t.Errorf("unexpected empty Markdown output %q", s)
+			}
+		}},
+	} {
+		for _, n := range []int{0, 1, 3} {
+			t.Run(c.file+"/"+strconv.Itoa(n), func(t *testing.T) {
+				c.check(t, writeOutput(t, c.file, newResults(n)), n)
+			})
+		}
+	}
+}
+
+func Test_Stream(t *testing.T) {
+	for _, body := range []bool{false, true} {
+		var (
+			buf     bytes.Buffer
+			results = newResults(2)
+			stream  = output.NewStream(&buf, body)
+		)
+		results = append(results[:1], output.ResultFinal{RequestId: 100}, results[1])
+		for _, r := range results {
+			r.Response.Body = "line 1\nline 2"
+			if err := stream.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// One compact JSON object per line and the results that are not OK are skipped:
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 2 || !strings.HasSuffix(buf.String(), "\n") {
+			t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+		}
+		for idx, line := range lines {
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, []byte(line)); err != nil || compact.String() != line {
+				t.Errorf("line %d is not a compact JSON object (%v): %s", idx+1, err, line)
+			}
+			var r output.ResultFinal
+			json.Unmarshal([]byte(line), &r)
+			if r.RequestId != idx+1 {
+				t.Errorf("got the request id %d on line %d", r.RequestId, idx+1)
+			}
+			// The body is only included when it's set:
+			if want := map[bool]string{true: "line 1\nline 2"}[body]; r.Response.Body != want {
+				t.Errorf("got the body %q on line %d, want %q", r.Response.Body, idx+1, want)
+			}
+		}
+	}
+}
+
+func Test_Convert(t *testing.T) {
+	// Results file with the response body and three similar results (same target, insert point and technique):
+	input := filepath.Join(t.TempDir(), "result.json")
+	w, err := output.NewWriter(input, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range newResults(3) {
+		r.TargetHashId = "target"
+		r.Request.URLOriginal = "http://example.com/?q=FUZZ"
+		r.Response.Body = "body " + strconv.Itoa(r.RequestId)
+		w.Write(r)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		cluster bool
+		want    int
+	}{
+		{false, 3},
+		{true, 1},
+	} {
+		path := filepath.Join(t.TempDir(), "result.jsonl")
+		n, err := output.Convert(input, path, "", c.cluster)
+		if err != nil || n != c.want {
+			t.Fatalf("cluster %v: converted %d results (%v), want %d", c.cluster, n, err, c.want)
+		}
+		results, err := output.ReadResults(path)
+		if err != nil || len(results) != c.want {
+			t.Fatalf("cluster %v: read %d results (%v), want %d", c.cluster, len(results), err, c.want)
+		}
+		for idx, r := range results {
+			if r.RequestId != idx+1 || r.Response.Body != "body "+strconv.Itoa(idx+1) || r.Payload != "'" {
+				t.Errorf("cluster %v: unexpected converted result %+v", c.cluster, r)
+			}
+		}
+	}
+
+	// The converted results file can be converted again (JSON Lines to CSV):
+	jsonl := filepath.Join(t.TempDir(), "result.jsonl")
+	output.Convert(input, jsonl, "", false)
+	path := filepath.Join(t.TempDir(), "findings.csv")
+	if n, err := output.Convert(jsonl, path, "", false); err != nil || n != 3 {
+		t.Fatalf("converted %d results (%v), want 3", n, err)
+	}
+	data, _ := os.ReadFile(path)
+	if records, err := csv.NewReader(bytes.NewReader(data)).ReadAll(); err != nil || len(records) != 4 {
+		t.Errorf("unexpected converted CSV (%v):\n%s", err, data)
+	}
+
+	if _, err := output.Convert(writeFixture(t, "invalid.jsonl", "{\"RequestId\": 1}\n{"), path, "", false); err == nil {
+		t.Error("an error was expected for an invalid results file")
+	}
+}
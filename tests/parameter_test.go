@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/insertpoint"
+	"github.com/Brum3ns/firefly/pkg/parameter"
+)
+
+func Test_ParameterJSON(t *testing.T) {
+	body := `{"user": {"name": "bob", "roles": ["a", "b"]}, "id": 1, "ok": true, "n": null, "list": [[1.5], {"x": "2"}]}`
+	want := []struct {
+		name string
+		raw  string
+	}{
+		{"user.name", `"bob"`},
+		{"user.roles[0]", `"a"`},
+		{"user.roles[1]", `"b"`},
+		{"id", `1`},
+		{"ok", `true`},
+		{"n", `null`},
+		{"list[0][0]", `1.5`},
+		{"list[1].x", `"2"`},
+	}
+
+	p := newParameter(t, "json", "replace")
+	if err := p.SetJSONparams(body); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.JSON.Params) != len(want) {
+		t.Fatalf("got %d JSON params, want %d: %+v", len(p.JSON.Params), len(want), p.JSON.Params)
+	}
+	for idx, param := range p.JSON.Params {
+		if param.Name != want[idx].name || param.Raw != want[idx].raw || body[param.Offset[0]:param.Offset[1]] != want[idx].raw {
+			t.Errorf("unexpected JSON param %d: %+v (want %+v)", idx, param, want[idx])
+		}
+	}
+	if got, want := p.JSON.RawQueryInsertPoint, `{"user": {"name": "FUZZ", "roles": ["FUZZ", "FUZZ"]}, "id": FUZZ, "ok": FUZZ, "n": FUZZ, "list": [[FUZZ], {"x": "FUZZ"}]}`; got != want {
+		t.Errorf("unexpected JSON insert point (replace):\n%s\nwant\n%s", got, want)
+	}
+
+	p = newParameter(t, "json", "append")
+	if err := p.SetJSONparams(body); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.JSON.RawQueryInsertPoint, `{"user": {"name": "bobFUZZ", "roles": ["aFUZZ", "bFUZZ"]}, "id": 1FUZZ, "ok": trueFUZZ, "n": nullFUZZ, "list": [[1.5FUZZ], {"x": "2FUZZ"}]}`; got != want {
+		t.Errorf("unexpected JSON insert point (append):\n%s\nwant\n%s", got, want)
+	}
+
+	// The payload is escaped within JSON strings and inserted as it is outside of strings:
+	insert := insertpoint.NewInsert("FUZZ", `1"\`)
+	if got, want := insert.SetJSONPostBody(`{"a": "FUZZ", "b": FUZZ, "c": "x\"FUZZ"}`), `{"a": "1\"\\", "b": 1"\, "c": "x\"1\"\\"}`; got != want {
+		t.Errorf("unexpected JSON post body:\n%s\nwant\n%s", got, want)
+	}
+
+	for _, invalid := range []string{`{"a": `, `{}`} {
+		invalidParam := newParameter(t, "json", "replace")
+		if err := invalidParam.SetJSONparams(invalid); err == nil {
+			t.Errorf("an error was expected for the JSON body %s", invalid)
+		}
+	}
+	if p := p.GetJSONParam("user.roles[1]"); len(p) != 1 || !reflect.DeepEqual(p[0].Value, "b") {
+		t.Errorf("unexpected JSON param %+v", p)
+	}
+}
+
+func Test_ParameterXML(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <login type="basic" id='7'>
+      <user> bob </user>
+      <pass><![CDATA[a<b]]></pass>
+      <empty></empty>
+      <self/>
+    </login>
+  </soap:Body>
+</soap:Envelope>`
+	want := []struct {
+		name string
+		raw  string
+	}{
+		{"soap:Envelope/soap:Body/login@type", "basic"},
+		{"soap:Envelope/soap:Body/login@id", "7"},
+		{"soap:Envelope/soap:Body/login/user", "bob"},
+		{"soap:Envelope/soap:Body/login/pass", "a<b"},
+		{"soap:Envelope/soap:Body/login/empty", ""},
+	}
+
+	p := newParameter(t, "xml", "replace")
+	if err := p.SetXMLparams(body); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.XML.Params) != len(want) {
+		t.Fatalf("got %d XML params, want %d: %+v", len(p.XML.Params), len(want), p.XML.Params)
+	}
+	for idx, param := range p.XML.Params {
+		if param.Name != want[idx].name || param.Raw != want[idx].raw || body[param.Offset[0]:param.Offset[1]] != want[idx].raw || param.Index != idx {
+			t.Errorf("unexpected XML param %d: %+v (want %+v)", idx, param, want[idx])
+		}
+	}
+	if got, want := p.XML.RawQueryInsertPoint, `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <login type="FUZZ" id='FUZZ'>
+      <user> FUZZ </user>
+      <pass><![CDATA[FUZZ]]></pass>
+      <empty>FUZZ</empty>
+      <self/>
+    </login>
+  </soap:Body>
+</soap:Envelope>`; got != want {
+		t.Errorf("unexpected XML insert point (replace):\n%s\nwant\n%s", got, want)
+	}
+
+	p = newParameter(t, "xml", "append")
+	if err := p.SetXMLparams(`<a x="1"><b>2</b></a>`); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.XML.RawQueryInsertPoint, `<a x="1FUZZ"><b>2FUZZ</b></a>`; got != want {
+		t.Errorf("unexpected XML insert point (append): %s, want %s", got, want)
+	}
+
+	// The payload is escaped outside of CDATA sections and the CDATA end is split within them:
+	insert := insertpoint.NewInsert("FUZZ", `<x>]]>&`)
+	if got, want := insert.SetXMLPostBody(`<a t="FUZZ">FUZZ<![CDATA[FUZZ]]></a>`), `<a t="&lt;x&gt;]]&gt;&amp;">&lt;x&gt;]]&gt;&amp;<![CDATA[<x>]]]]><![CDATA[>&]]></a>`; got != want {
+		t.Errorf("unexpected XML post body:\n%s\nwant\n%s", got, want)
+	}
+
+	for _, invalid := range []string{`</a>`, `<a/>`} {
+		invalidParam := newParameter(t, "xml", "replace")
+		if err := invalidParam.SetXMLparams(invalid); err == nil {
+			t.Errorf("an error was expected for the XML body %s", invalid)
+		}
+	}
+}
+
+func Test_ParameterMultipart(t *testing.T) {
+	body := "--XyZ\r\n" +
+		"Content-Disposition: form-data; name=\"a\"\r\n" +
+		"\r\n" +
+		"1\r\n" +
+		"--XyZ\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"t.txt\"\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"hello\nworld\r\n" +
+		"--XyZ--\r\n"
+	want := []struct {
+		name string
+		raw  string
+	}{
+		{"a", "1"},
+		{"file", "hello\nworld"},
+		{"file.filename", "t.txt"},
+		{"file.content-type", "text/plain"},
+	}
+
+	p := newParameter(t, "multipart", "replace")
+	if err := p.SetMultipartparams(body, `multipart/form-data; boundary="XyZ"`); err != nil {
+		t.Fatal(err)
+	}
+	if p.Multipart.Boundary != "XyZ" || len(p.Multipart.Params) != len(want) {
+		t.Fatalf("unexpected multipart params (boundary %q): %+v", p.Multipart.Boundary, p.Multipart.Params)
+	}
+	for idx, param := range p.Multipart.Params {
+		if param.Name != want[idx].name || param.Raw != want[idx].raw || body[param.Offset[0]:param.Offset[1]] != want[idx].raw {
+			t.Errorf("unexpected multipart param %d: %+v (want %+v)", idx, param, want[idx])
+		}
+	}
+	replaced := "--XyZ\r\n" +
+		"Content-Disposition: form-data; name=\"a\"\r\n" +
+		"\r\n" +
+		"FUZZ\r\n" +
+		"--XyZ\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"FUZZ\"\r\n" +
+		"Content-Type: FUZZ\r\n" +
+		"\r\n" +
+		"FUZZ\r\n" +
+		"--XyZ--\r\n"
+	if got := p.Multipart.RawQueryInsertPoint; got != replaced {
+		t.Errorf("unexpected multipart insert point (replace):\n%q\nwant\n%q", got, replaced)
+	}
+
+	// The payload is encoded within the part headers and a new boundary is used when the payload contains the boundary:
+	insert := insertpoint.NewInsert("FUZZ", "\"--XyZ\"")
+	got, boundary := insert.SetMultipartPostBody(replaced, "XyZ")
+	if len(boundary) <= len("XyZ") || boundary[:3] != "XyZ" {
+		t.Fatalf("a new boundary was expected, got %q", boundary)
+	}
+	want2 := "--" + boundary + "\r\n" +
+		"Content-Disposition: form-data; name=\"a\"\r\n" +
+		"\r\n" +
+		"\"--XyZ\"\r\n" +
+		"--" + boundary + "\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"%22--XyZ%22\"\r\n" +
+		"Content-Type: %22--XyZ%22\r\n" +
+		"\r\n" +
+		"\"--XyZ\"\r\n" +
+		"--" + boundary + "--\r\n"
+	if got != want2 {
+		t.Errorf("unexpected multipart post body:\n%q\nwant\n%q", got, want2)
+	}
+
+	for contentType, invalid := range map[string]string{
+		"multipart/form-data":               "--XyZ\r\n\r\n",
+		"multipart/form-data; boundary=XyZ": "no boundary",
+		"multipart/form-data; boundary=Xy":  "--Xy\r\nContent-Disposition: form-data; name=\"a\"\r\n\r\n1\r\n",
+		"application/json; boundary=XyZ":    "--XyZ\r\n\r\n",
+	} {
+		invalidParam := newParameter(t, "multipart", "replace")
+		if err := invalidParam.SetMultipartparams(invalid, contentType); err == nil {
+			t.Errorf("an error was expected for the multipart body %q (%s)", invalid, contentType)
+		}
+	}
+}
+
+func Test_ParameterHeader(t *testing.T) {
+	headers := [][2]string{{"user-agent", "firefly"}, {"cookie", "a=1"}, {"x-api", " 2"}, {"Origin", "http://example.com"}}
+
+	p := newParameter(t, "header", "replace")
+	p.SetHeaderparams(headers, false)
+	var names []string
+	for _, param := range p.Header.Params {
+		names = append(names, param.Name)
+	}
+	// The cookie header has its own position:
+	if !reflect.DeepEqual(names, []string{"user-agent", "x-api", "Origin"}) {
+		t.Fatalf("unexpected header params %v", names)
+	}
+	want := [][][2]string{
+		{{"user-agent", "FUZZ"}, {"cookie", "a=1"}, {"x-api", " 2"}, {"Origin", "http://example.com"}},
+		{{"user-agent", "firefly"}, {"cookie", "a=1"}, {"x-api", "FUZZ"}, {"Origin", "http://example.com"}},
+		{{"user-agent", "firefly"}, {"cookie", "a=1"}, {"x-api", " 2"}, {"Origin", "FUZZ"}},
+	}
+	if got := p.HeaderInsertPoints(headers); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected header insert points:\n%v\nwant\n%v", got, want)
+	}
+	if headers[0][1] != "firefly" {
+		t.Error("the given headers must not be modified")
+	}
+
+	// The interesting headers are added to the end of the headers (unless already given):
+	p = newParameter(t, "header", "append")
+	p.SetHeaderparams(headers, true)
+	if n := len(p.Header.Params); n != 3+len(parameter.INTERESTING_HEADERS)-1 {
+		t.Fatalf("got %d header params with the interesting headers", n)
+	}
+	points := p.HeaderInsertPoints(headers)
+	if got := points[1][2]; got != [2]string{"x-api", " 2FUZZ"} {
+		t.Errorf("unexpected appended header %v", got)
+	}
+	if got := points[3]; len(got) != len(headers)+1 || got[len(headers)] != [2]string{"X-Forwarded-For", "127.0.0.1FUZZ"} {
+		t.Errorf("unexpected interesting header insert point %v", got)
+	}
+}
+
+func Test_ParameterPath(t *testing.T) {
+	p := newParameter(t, "path", "replace")
+	p.SetPathparams("/api//v1/index.php")
+	want := []struct {
+		name string
+		raw  string
+	}{
+		{"path[0]", "api"},
+		{"path[1]", "v1"},
+		{"path[2]", "index.php"},
+		{"path[2].extension", "php"},
+	}
+	if len(p.Path.Params) != len(want) {
+		t.Fatalf("got %d path params, want %d: %+v", len(p.Path.Params), len(want), p.Path.Params)
+	}
+	for idx, param := range p.Path.Params {
+		if param.Name != want[idx].name || param.Raw != want[idx].raw || "/api//v1/index.php"[param.Offset[0]:param.Offset[1]] != want[idx].raw {
+			t.Errorf("unexpected path param %d: %+v (want %+v)", idx, param, want[idx])
+		}
+	}
+	if got, want := p.Path.RawQueryInsertPoints, []string{"/FUZZ//v1/index.php", "/api//FUZZ/index.php", "/api//v1/FUZZ", "/api//v1/index.FUZZ"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path insert points (replace):\n%v\nwant\n%v", got, want)
+	}
+
+	// Only the last segment has a file extension:
+	p = newParameter(t, "path", "append")
+	p.SetPathparams("/v1.2/users/")
+	if got, want := p.Path.RawQueryInsertPoints, []string{"/v1.2FUZZ/users/", "/v1.2/usersFUZZ/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path insert points (append):\n%v\nwant\n%v", got, want)
+	}
+}
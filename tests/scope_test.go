@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Brum3ns/firefly/internal/option"
+	"github.com/Brum3ns/firefly/pkg/scope"
+)
+
+func Test_ScopeFile(t *testing.T) {
+	jsonl := `# Comment and empty lines are ignored
+
+{"url": " http://a.example.com/?q=1 ", "method": "post", "headers": {"X-B": "2", "X-A": 1}, "body": "a=1", "cookies": {"sid": "x", "lang": "en"}, "auto-params": "body:append", "wordlist": "users.txt"}
+{"url": "https://b.example.com/", "headers": ["Authorization: Bearer abc:def"]}
+`
+	yml := `- url: http://a.example.com/?q=1
+  method: post
+  headers:
+    X-B: 2
+    X-A: 1
+  body: a=1
+  cookies:
+    sid: x
+    lang: en
+  auto-params: body:append
+  wordlist: users.txt
+- url: https://b.example.com/
+  headers:
+    - "Authorization: Bearer abc:def"
+`
+	want := []scope.Target{
+		{
+			URL:        "http://a.example.com/?q=1",
+			Method:     "POST",
+			Headers:    [][2]string{{"x-a", "1"}, {"x-b", "2"}},
+			Body:       "a=1",
+			Cookies:    map[string]string{"sid": "x", "lang": "en"},
+			AutoParams: "body:append",
+			Wordlist:   "users.txt",
+		},
+		{
+			URL:     "https://b.example.com/",
+			Headers: [][2]string{{"authorization", "Bearer abc:def"}},
+		},
+	}
+
+	for name, content := range map[string]string{"scope.jsonl": jsonl, "scope.yml": yml} {
+		targets, err := scope.ReadFile(writeFixture(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if !reflect.DeepEqual(targets, want) {
+			t.Errorf("%s: got the targets\n%+v\nwant\n%+v", name, targets, want)
+		}
+		if c := targets[0].CookieHeader(); c != "lang=en; sid=x" {
+			t.Errorf("%s: unexpected cookie header %q", name, c)
+		}
+	}
+
+	// Invalid scope files:
+	for name, content := range map[string]string{
+		"missing-url.jsonl":    `{"method": "GET"}`,
+		"invalid-header.jsonl": `{"url": "http://a.example.com/", "headers": ["no separator"]}`,
+		"invalid.jsonl":        `{"url": `,
+		"scope.txt":            `http://a.example.com/`,
+	} {
+		if _, err := scope.ReadFile(writeFixture(t, name, content)); err == nil {
+			t.Errorf("%s: an error was expected", name)
+		}
+	}
+}
+
+func Test_ScopeTargetHeaders(t *testing.T) {
+	global := [][2]string{{"user-agent", "firefly"}, {"x-api", "global"}, {"cookie", "a=1"}}
+	for _, c := range []struct {
+		target [][2]string
+		cookie string
+		want   [][2]string
+	}{
+		// The target headers replace the global headers with the same name:
+		{[][2]string{{"x-api", "target"}}, "", [][2]string{{"user-agent", "firefly"}, {"cookie", "a=1"}, {"x-api", "target"}}},
+		// The target cookies are added to the cookie header:
+		{nil, "b=2", [][2]string{{"user-agent", "firefly"}, {"x-api", "global"}, {"cookie", "a=1; b=2"}}},
+		{[][2]string{{"cookie", "c=3"}}, "b=2", [][2]string{{"user-agent", "firefly"}, {"x-api", "global"}, {"cookie", "c=3; b=2"}}},
+	} {
+		if got := option.MergeHeaders(global, c.target, c.cookie); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("MergeHeaders(%v, %q): got %v, want %v", c.target, c.cookie, got, c.want)
+		}
+	}
+	if got := option.MergeHeaders(nil, nil, "b=2"); !reflect.DeepEqual(got, [][2]string{{"cookie", "b=2"}}) {
+		t.Errorf("a cookie header must be added when missing: %v", got)
+	}
+}
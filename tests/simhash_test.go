@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/httpprepare"
+	"github.com/Brum3ns/firefly/pkg/simhash"
+)
+
+func Test_Simhash(t *testing.T) {
+	page := func(s string) uint64 {
+		return simhash.FromHTMLNode(httpprepare.GetHTMLNode("<html><body><h1>Products</h1><ul>" + s + "</ul><p>" + strings.Repeat("lorem ipsum dolor sit amet ", 20) + "</p></body></html>"))
+	}
+	var (
+		baseline = page("<li>apple</li><li>banana</li><li>cherry</li>")
+		dynamic  = page("<li>apple</li><li>banana</li><li>cherry</li><!-- 1337 -->")
+		changed  = simhash.FromHTMLNode(httpprepare.GetHTMLNode(`<html><body><div class="error">You have an error in your SQL syntax near '' at line 1</div><pre>Warning: mysql_fetch_array() expects parameter 1</pre></body></html>`))
+	)
+
+	if s := simhash.Similarity(baseline, baseline); s != 1 {
+		t.Errorf("identical bodies: got similarity %v, want 1", s)
+	}
+	similar, different := simhash.MaxSimilarity(dynamic, []uint64{baseline}), simhash.MaxSimilarity(changed, []uint64{baseline})
+	if similar <= different {
+		t.Errorf("a tiny change (%v) must be more similar than a different body (%v)", similar, different)
+	}
+	if similar < 0.9 {
+		t.Errorf("a tiny change must have a high similarity, got %v", similar)
+	}
+	if s := simhash.MaxSimilarity(changed, nil); s != 1 {
+		t.Errorf("no baseline: got similarity %v, want 1", s)
+	}
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Brum3ns/firefly/internal/output"
+)
+
+type testSink struct {
+	results []output.ResultFinal
+	err     error
+	closed  bool
+}
+
+func (s *testSink) Open() error  { return nil }
+func (s *testSink) Close() error { s.closed = true; return nil }
+func (s *testSink) Write(result output.ResultFinal) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.results = append(s.results, result)
+	return nil
+}
+
+func Test_Sinks(t *testing.T) {
+	var (
+		sinks  = output.NewSinks()
+		all    = &testSink{}
+		behave = &testSink{}
+		failed = &testSink{err: errors.New("failed")}
+	)
+	sinks.Register("all", all, nil)
+	sinks.Register("behavior", behave, func(result output.ResultFinal) bool {
+		return result.UnkownBehavior
+	})
+	sinks.Register("failed", failed, nil)
+
+	if err := sinks.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := sinks.Write(output.ResultFinal{RequestId: 1}); count != 1 || err == nil {
+		t.Errorf("got %d sinks written and error %v, want 1 and an error", count, err)
+	}
+	if count, _ := sinks.Write(output.ResultFinal{RequestId: 2, UnkownBehavior: true}); count != 2 {
+		t.Errorf("got %d sinks written, want 2", count)
+	}
+	if err := sinks.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all.results) != 2 || len(behave.results) != 1 || behave.results[0].RequestId != 2 {
+		t.Errorf("unexpected results: all %v, behavior %v", all.results, behave.results)
+	}
+	if !all.closed || !behave.closed || !failed.closed {
+		t.Error("all sinks must be closed")
+	}
+}
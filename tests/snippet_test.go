@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/extract"
+	"github.com/Brum3ns/firefly/pkg/snippet"
+	"github.com/Brum3ns/firefly/pkg/transformation"
+)
+
+func Test_Snippet(t *testing.T) {
+	s := "ééé error in your SQL syntax near 'x'\nline 2"
+	start := strings.Index(s, "SQL syntax")
+	sn := snippet.New("body", s, start, start+len("SQL syntax"), 16)
+	// Note : (The window start within the UTF-8 character "é" and is moved back to not split it)
+	if sn.Before != "é error in your " || sn.Match != "SQL syntax" || sn.After != " near 'x'\nline 2" {
+		t.Errorf("unexpected snippet %+v", sn)
+	}
+	if got := sn.String(); got != `é error in your »SQL syntax« near 'x'\nline 2` {
+		t.Errorf("unexpected snippet string %q", got)
+	}
+
+	// Extract (the first matches of each item are kept):
+	e, err := extract.NewExtract(extract.Properties{
+		Rules:   extract.WordlistRules("test", []string{"SQL"}, []string{`line [0-9]`}),
+		Context: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := strings.Repeat("x SQL ", 5) + "line 1"
+	e.AddJob(body, "Server: SQL")
+	result := e.Run()
+	if n := len(result.Context["SQL"]); n != snippet.LIMIT {
+		t.Errorf("got %d snippets of the pattern, want %d", n, snippet.LIMIT)
+	}
+	if c := result.Context["SQL"][0]; c.Source != "body" || c.Start != 2 || c.End != 5 || c.Before != "x " || c.After != " x SQ" {
+		t.Errorf("unexpected pattern snippet %+v", c)
+	}
+	if c := result.Context[`line [0-9]`]; len(c) != 1 || c[0].Match != "line 1" || c[0].Start != len(body)-6 {
+		t.Errorf("unexpected regex snippet %+v", c)
+	}
+
+	// Transformation:
+	yamlFile := writeFixture(t, "transformation.yml", "\"&lt;\":\n  - [\"<\", \"HTML encode\"]\n")
+	tfmt, err := transformation.NewTransformation(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tfmt.Context = 4
+	r := tfmt.Detect("<p>value: "+transformation.PREFIX+"&lt;"+transformation.SUFFIX+"</p>", transformation.PREFIX+"<"+transformation.SUFFIX)
+	if !r.OK || r.Context == nil || r.Context.Before != "ue: " || r.Context.After != "</p>" || r.Context.Start != 10 {
+		t.Errorf("unexpected transformation result %+v (context: %+v)", r, r.Context)
+	}
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Brum3ns/firefly/internal/output"
+	"github.com/Brum3ns/firefly/internal/runner"
+	"github.com/Brum3ns/firefly/pkg/parameter"
+	"github.com/Brum3ns/firefly/pkg/request"
+	"github.com/Brum3ns/firefly/pkg/timing"
+)
+
+func Test_Timing(t *testing.T) {
+	b := timing.NewBaseline([]float64{0.10, 0.12, 0.11, 0.09, 0.13}, 2)
+	if b.IsAnomaly(0.5) || !b.IsAnomaly(5.2) {
+		t.Errorf("unexpected anomaly detection with the baseline %+v", b)
+	}
+
+	for payload, want := range map[string]string{
+		"' AND sleep(5)-- -":         "' AND sleep(10)-- -",
+		"1;SELECT pg_sleep(3)":       "1;SELECT pg_sleep(6)",
+		"'; WAITFOR DELAY '0:0:5'--": "'; WAITFOR DELAY '0:0:10'--",
+		// The seconds are carried into the minutes and hours:
+		"'; WAITFOR DELAY '0:0:30'--":   "'; WAITFOR DELAY '0:1:0'--",
+		"'; WAITFOR DELAY '00:45:50'--": "'; WAITFOR DELAY '1:31:40'--",
+		"`sleep 4`":                     "`sleep 8`",
+		"| ping -c 5 127.0.0.1":         "| ping -c 10 127.0.0.1",
+	} {
+		scaled, delay, scaledDelay, ok := timing.ScalePayload(payload, 2)
+		if !ok || scaled != want || scaledDelay != delay*2 {
+			t.Errorf("%s: got %q (%v => %v), want %q", payload, scaled, delay, scaledDelay, want)
+		}
+	}
+	for _, payload := range []string{"<script>alert(1)</script>", "'; WAITFOR DELAY '12:0:0'--"} {
+		// Note : (The scaled delay of WAITFOR DELAY must be less than a day)
+		if _, _, _, ok := timing.ScalePayload(payload, 2); ok {
+			t.Errorf("%s: the payload can't be scaled", payload)
+		}
+	}
+
+	if !b.IsLinear(timing.Probe{Delay: 5, Time: 5.1}, timing.Probe{Delay: 10, Time: 10.3}) {
+		t.Error("the response times are linear to the delays")
+	}
+	if b.IsLinear(timing.Probe{Delay: 5, Time: 5.1}, timing.Probe{Delay: 10, Time: 5.2}) {
+		t.Error("the response times are not linear to the delays")
+	}
+}
+
+func Test_TimingAnalyzer(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		received []string
+		delay    = regexp.MustCompile(`sleep\((\d+)\)`)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query().Get("q")
+		mutex.Lock()
+		received = append(received, q)
+		mutex.Unlock()
+		if m := delay.FindStringSubmatch(q); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			time.Sleep(time.Duration(n) * 150 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := runner.NewTimingAnalyzer(
+		map[string]timing.Baseline{"target": timing.NewBaseline([]float64{0.01, 0.012, 0.011, 0.009}, 0.05)},
+		&http.Client{Timeout: 5 * time.Second},
+		func(result output.ResultFinal, payload string) request.RequestSettings {
+			return runner.RebuildRequest(result, payload, "FUZZ", parameter.Parameter{})
+		},
+	)
+	// The payload is URL encoded within the URL (Ex: " " => "%20"):
+	result := output.ResultFinal{OK: true, RequestId: 1, TargetHashId: "target", Payload: "' AND sleep(1)-- -"}
+	result.Request.URLOriginal = server.URL + "/?q=FUZZ"
+	result.Request.URL = server.URL + "/?q='%20AND%20sleep(1)--%20-"
+	result.Request.Method = "GET"
+	result.Response.Time = 0.16
+	if !analyzer.Suspicious(result) {
+		t.Fatal("the response time must be suspicious")
+	}
+
+	channel := make(chan output.ResultFinal, 1)
+	if !analyzer.Analyze(result, channel) {
+		t.Fatal("the result must be analyzed")
+	}
+	analyzer.Close()
+	r := <-channel
+	if tr := r.Scanner.Timing; !tr.Checked || len(tr.Probes) != 2 || tr.Probes[1].Payload != "' AND sleep(2)-- -" || !tr.Linear || !tr.OK {
+		t.Errorf("unexpected timing result %+v", tr)
+	}
+	// The scaled payload is inserted into the insert point:
+	if want := []string{"' AND sleep(1)-- -", "' AND sleep(2)-- -"}; !reflect.DeepEqual(received, want) {
+		t.Errorf("got the payloads %q, want %q", received, want)
+	}
+	if analyzer.Suspicious(r) {
+		t.Error("an analyzed result must not be analyzed again")
+	}
+	if analyzer.Analyze(result, channel) {
+		t.Error("a closed analyzer must not analyze results")
+	}
+}
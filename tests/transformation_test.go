@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Brum3ns/firefly/pkg/transformation"
+)
+
+func Test_TransformationInfer(t *testing.T) {
+	for _, c := range []struct {
+		sent, reflected string
+		categories      []string
+		mapping         map[string]string
+	}{
+		{`<a'>`, `&lt;a&#39;&gt;`, []string{transformation.HTML_ENCODE}, map[string]string{"<": "&lt;", "'": "&#39;", ">": "&gt;"}},
+		{`%3Cx%3E`, `<x>`, []string{transformation.URL_DECODE}, map[string]string{"%3C": "<", "%3E": ">"}},
+		{`%253Cx`, `<x`, []string{transformation.DOUBLE_DECODE}, map[string]string{"%253C": "<"}},
+		{`AbC`, `abc`, []string{transformation.CASE_FOLD}, map[string]string{"A": "a", "C": "c"}},
+		{`＜x／`, `<x/`, []string{transformation.NFKC}, map[string]string{"＜": "<", "／": "/"}},
+		{`abcdef`, `abc`, []string{transformation.TRUNCATE}, map[string]string{"def": ""}},
+		{`a"b'c`, `abc`, []string{transformation.STRIP}, map[string]string{`"`: "", "'": ""}},
+		{`%3CX`, `&lt;x`, []string{transformation.URL_DECODE, transformation.CASE_FOLD, transformation.HTML_ENCODE}, map[string]string{"<": "&lt;"}},
+	} {
+		steps, ok := transformation.Infer(c.sent, c.reflected)
+		if !ok {
+			t.Errorf("no transformation inferred from %q to %q", c.sent, c.reflected)
+			continue
+		}
+		var categories []string
+		for _, s := range steps {
+			categories = append(categories, s.Category)
+		}
+		if !reflect.DeepEqual(categories, c.categories) {
+			t.Errorf("%q => %q: got the steps %v, want %v", c.sent, c.reflected, categories, c.categories)
+		}
+		if last := steps[len(steps)-1].Mapping; !reflect.DeepEqual(last, c.mapping) {
+			t.Errorf("%q => %q: got the mapping %v, want %v", c.sent, c.reflected, last, c.mapping)
+		}
+	}
+
+	// Unchanged and unrelated reflections are not transformations:
+	for _, c := range [][2]string{{"abc", "abc"}, {"abc", "xyz"}, {"abc", ""}} {
+		if steps, ok := transformation.Infer(c[0], c[1]); ok {
+			t.Errorf("%q => %q: unexpected steps %v", c[0], c[1], steps)
+		}
+	}
+
+	// Detect infer the transformation when the reflected payload is not the expected one:
+	yamlFile := writeFixture(t, "transformation.yml", "\"&lt;\":\n  - [\"<\", \"HTML encode\"]\n")
+	tfmt, err := transformation.NewTransformation(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := tfmt.Detect("<p>"+transformation.PREFIX+"\"x"+transformation.SUFFIX+"</p>", transformation.PREFIX+"\"'x"+transformation.SUFFIX)
+	if !r.OK || r.Desc != "inferred strip" || r.Format != `"x` || len(r.Steps) != 1 {
+		t.Errorf("unexpected inferred result %+v", r)
+	}
+	if r := tfmt.Detect("<p>"+transformation.PREFIX+"&lt;"+transformation.SUFFIX+"</p>", transformation.PREFIX+"<"+transformation.SUFFIX); !r.OK || r.Desc != "HTML encode" || transformation.Describe(r.Steps) != transformation.HTML_ENCODE {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if r := tfmt.Detect(transformation.PREFIX+"x"+transformation.SUFFIX, "x"); r.OK {
+		t.Errorf("a payload without the prefix and suffix must not be detected %+v", r)
+	}
+}
+
+func Test_UnicodeVariants(t *testing.T) {
+	variants := transformation.UnicodeVariants([]rune{'<', '\''})
+	kinds := make(map[rune]transformation.Variant)
+	for _, v := range variants {
+		if v.Char != '<' && v.Char != '\'' {
+			t.Errorf("unexpected variant %+v", v)
+		}
+		kinds[v.Variant] = v
+	}
+	for r, kind := range map[rune]string{'＜': transformation.UNICODE_COMPATIBILITY, '﹤': transformation.UNICODE_COMPATIBILITY, '＇': transformation.UNICODE_COMPATIBILITY, '‹': transformation.UNICODE_CONFUSABLE, 'ʼ': transformation.UNICODE_CONFUSABLE} {
+		if kinds[r].Kind != kind {
+			t.Errorf("the variant %q has the kind %q, want %q", r, kinds[r].Kind, kind)
+		}
+	}
+	if wl := transformation.VariantWordlist(variants); len(wl) != len(variants) || wl[0] != transformation.PREFIX+string(variants[0].Variant)+transformation.SUFFIX {
+		t.Errorf("unexpected variant wordlist %v", wl)
+	}
+
+	// Detect which variant collapsed to which ASCII character:
+	yamlFile := writeFixture(t, "transformation.yml", "{}\n")
+	tfmt, err := transformation.NewTransformation(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tfmt.AddVariants(variants)
+	for _, c := range []struct {
+		variant, desc, category string
+	}{
+		{"＜", "unicode-compatibility U+FF1C", transformation.NFKC},
+		{"‹", "unicode-confusable U+2039", transformation.BEST_FIT},
+	} {
+		r := tfmt.Detect("<p>"+transformation.PREFIX+"<"+transformation.SUFFIX+"</p>", transformation.PREFIX+c.variant+transformation.SUFFIX)
+		if !r.OK || r.Desc != c.desc || r.Format != "<" || len(r.Steps) != 1 || r.Steps[0].Category != c.category || r.Steps[0].Mapping[c.variant] != "<" {
+			t.Errorf("unexpected result of the variant %q: %+v", c.variant, r)
+		}
+	}
+	if r := tfmt.Detect("<p>"+transformation.PREFIX+"＜"+transformation.SUFFIX+"</p>", transformation.PREFIX+"＜"+transformation.SUFFIX); r.OK {
+		t.Errorf("an unchanged variant must not be detected %+v", r)
+	}
+}
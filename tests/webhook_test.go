@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Brum3ns/firefly/internal/output"
+)
+
+func Test_Webhook(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		attempts int
+		batches  [][]output.ResultFinal
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		// The first request fails and must be retried:
+		if attempts++; attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var batch []output.ResultFinal
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &batch); err != nil {
+			t.Errorf("invalid webhook payload: %s", err)
+		}
+		batches = append(batches, batch)
+	}))
+	defer server.Close()
+
+	webhook, err := output.NewWebhook(output.WebhookConfig{
+		URL:        server.URL,
+		Batch:      2,
+		Retry:      1,
+		RetryDelay: time.Millisecond,
+		MinScore:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := func(id int, transformed bool) output.ResultFinal {
+		r := output.ResultFinal{OK: true, RequestId: id}
+		r.Scanner.Transformation.OK = transformed
+		return r
+	}
+	// The result with the id "2" has the score zero and must be filtered:
+	for idx, ok := range []bool{true, false, true, true} {
+		if err := webhook.Write(result(idx+1, ok)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := webhook.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("got %d webhook requests, want 3", attempts)
+	}
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+	if batches[0][0].RequestId != 1 || batches[0][1].RequestId != 3 || batches[1][0].RequestId != 4 {
+		t.Errorf("unexpected results in the batches: %v", batches)
+	}
+}
+
+func Test_WebhookQueueFull(t *testing.T) {
+	// The webhook is down, each batch is retried while the results keep coming:
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook, err := output.NewWebhook(output.WebhookConfig{
+		URL:        server.URL,
+		Batch:      1,
+		Retry:      2,
+		RetryDelay: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 100; i++ {
+			if err := webhook.Write(output.ResultFinal{OK: true, RequestId: i}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the writes are blocked by the webhook sender")
+	}
+
+	// Close wait for the queued batches and return the error of the sender:
+	if err := webhook.Close(); err == nil {
+		t.Error("the error of the sender must be returned by Close")
+	}
+}